@@ -0,0 +1,86 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/safe"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/siderolabs/gen/optional"
+	"go.uber.org/zap"
+
+	"github.com/siderolabs/talos/pkg/machinery/config/config"
+	"github.com/siderolabs/talos/pkg/machinery/constants"
+	runtimeres "github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+)
+
+// PCRSigningKeyConfigController resolves the PCR signing key backend URI
+// from the machine config, defaulting to the on-disk PEM file Talos has
+// always used. PCRSigningKeysConfigController reads this resource as its
+// single-key fallback instead of reaching into the machine config
+// directly, so that key resolution has one source of truth.
+type PCRSigningKeyConfigController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *PCRSigningKeyConfigController) Name() string {
+	return "runtime.PCRSigningKeyConfigController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *PCRSigningKeyConfigController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        optional.Some(config.ActiveID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *PCRSigningKeyConfigController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtimeres.PCRSigningKeyConfigType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *PCRSigningKeyConfigController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := safe.ReaderGetByID[*config.MachineConfig](ctx, r, config.ActiveID)
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("failed to get machine config: %w", err)
+		}
+
+		keyURI := "pem:" + constants.PCRPublicKey
+
+		if cfg != nil {
+			if uri := cfg.Config().Security().PCRSigningKeyURI(); uri != "" {
+				keyURI = uri
+			}
+		}
+
+		if err = safe.WriterModify(ctx, r, runtimeres.NewPCRSigningKeyConfigSpec(runtimeres.NamespaceName), func(spec *runtimeres.PCRSigningKeyConfig) error {
+			spec.TypedSpec().KeyURI = keyURI
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to update PCRSigningKeyConfig: %w", err)
+		}
+	}
+}
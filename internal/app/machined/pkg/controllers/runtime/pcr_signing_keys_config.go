@@ -0,0 +1,121 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/safe"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/siderolabs/gen/optional"
+	"go.uber.org/zap"
+
+	"github.com/siderolabs/talos/pkg/machinery/config/config"
+	runtimeres "github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+)
+
+// PCRSigningKeysConfigController resolves the set of keys accepted for PCR
+// signature verification from the machine config, falling back to a single
+// "current" entry wrapping PCRSigningKeyConfig when the machine config
+// doesn't list any explicitly. This is the input SecurityStateController
+// reads to support key rotation without requiring a reboot; it is the
+// intended input for UKI and image-cache verification too, once those
+// call sites exist and are wired up to pkg/pcr.
+type PCRSigningKeysConfigController struct{}
+
+// Name implements controller.Controller interface.
+func (ctrl *PCRSigningKeysConfigController) Name() string {
+	return "runtime.PCRSigningKeysConfigController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *PCRSigningKeysConfigController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: config.NamespaceName,
+			Type:      config.MachineConfigType,
+			ID:        optional.Some(config.ActiveID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: runtimeres.NamespaceName,
+			Type:      runtimeres.PCRSigningKeyConfigType,
+			ID:        optional.Some(runtimeres.PCRSigningKeyConfigID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *PCRSigningKeysConfigController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtimeres.PCRSigningKeysConfigType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *PCRSigningKeysConfigController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
+
+		cfg, err := safe.ReaderGetByID[*config.MachineConfig](ctx, r, config.ActiveID)
+		if err != nil && !state.IsNotFoundError(err) {
+			return fmt.Errorf("failed to get machine config: %w", err)
+		}
+
+		var entries []runtimeres.PCRSigningKeyConfigEntry
+
+		var quorumThreshold uint32
+
+		if cfg != nil {
+			if configured := cfg.Config().Security().PCRSigningKeys(); len(configured) > 0 {
+				for _, k := range configured {
+					entries = append(entries, runtimeres.PCRSigningKeyConfigEntry{
+						URI:       k.URI(),
+						Role:      k.Role(),
+						NotBefore: k.NotBefore(),
+						NotAfter:  k.NotAfter(),
+					})
+				}
+
+				quorumThreshold = cfg.Config().Security().PCRSigningKeysQuorum()
+			}
+		}
+
+		if len(entries) == 0 {
+			keyConfig, err := safe.ReaderGetByID[*runtimeres.PCRSigningKeyConfig](ctx, r, runtimeres.PCRSigningKeyConfigID)
+			if err != nil && !state.IsNotFoundError(err) {
+				return fmt.Errorf("failed to get PCRSigningKeyConfig: %w", err)
+			}
+
+			if keyConfig != nil {
+				entries = []runtimeres.PCRSigningKeyConfigEntry{
+					{
+						URI:  keyConfig.TypedSpec().KeyURI,
+						Role: runtimeres.PCRSigningKeyRoleCurrent,
+					},
+				}
+			}
+		}
+
+		if err = safe.WriterModify(ctx, r, runtimeres.NewPCRSigningKeysConfigSpec(runtimeres.NamespaceName), func(spec *runtimeres.PCRSigningKeysConfig) error {
+			spec.TypedSpec().Keys = entries
+			spec.TypedSpec().QuorumThreshold = quorumThreshold
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to update PCRSigningKeysConfig: %w", err)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/safe"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/siderolabs/gen/optional"
+	"go.uber.org/zap"
+
+	"github.com/siderolabs/talos/pkg/machinery/resources/events"
+	runtimeres "github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+	"github.com/siderolabs/talos/pkg/revocation"
+)
+
+// DefaultRevocationCacheDir is where cached OCSP/CRL responses are kept
+// between checks, so a reboot without network access doesn't immediately
+// regress RevocationState to "unknown".
+const DefaultRevocationCacheDir = "/var/lib/talos/revocation-cache"
+
+// revocationCheckInterval is how often RevocationStateController
+// re-evaluates the PCR signing certificate.
+const revocationCheckInterval = time.Hour
+
+// RevocationStateController periodically evaluates revocation for the PCR
+// signing certificate via OCSP/CRL and records the result on SecurityState,
+// emitting a runtime event whenever a previously good certificate is found
+// to be revoked so that upgrades can be gated on it.
+type RevocationStateController struct {
+	// CacheDir overrides DefaultRevocationCacheDir, mainly for tests.
+	CacheDir string
+
+	// EventPublisher is used to emit a RevocationEvent on a good -> revoked
+	// transition. A nil EventPublisher disables event emission (e.g. in
+	// tests) but the controller still updates SecurityState.
+	EventPublisher events.Publisher
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *RevocationStateController) Name() string {
+	return "runtime.RevocationStateController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *RevocationStateController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: runtimeres.NamespaceName,
+			Type:      runtimeres.SecurityStateType,
+			ID:        optional.Some(runtimeres.SecurityStateID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *RevocationStateController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtimeres.SecurityStateType,
+			Kind: controller.OutputShared,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *RevocationStateController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	cacheDir := ctrl.CacheDir
+	if cacheDir == "" {
+		cacheDir = DefaultRevocationCacheDir
+	}
+
+	cache, err := revocation.NewCache(cacheDir)
+	if err != nil {
+		return fmt.Errorf("failed to open revocation cache: %w", err)
+	}
+
+	ticker := time.NewTicker(revocationCheckInterval)
+	defer ticker.Stop()
+
+	var lastState string
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		case <-ticker.C:
+		}
+
+		newState, err := ctrl.check(ctx, r, cache)
+		if err != nil {
+			logger.Warn("failed to evaluate PCR signing certificate revocation", zap.Error(err))
+
+			continue
+		}
+
+		if newState == "" {
+			continue
+		}
+
+		if lastState == runtimeres.RevocationStateGood && newState == runtimeres.RevocationStateRevoked && ctrl.EventPublisher != nil {
+			ctrl.EventPublisher.Publish(ctx, &events.RevocationEvent{
+				Message: "PCR signing certificate has been revoked",
+			})
+		}
+
+		lastState = newState
+	}
+}
+
+func (ctrl *RevocationStateController) check(ctx context.Context, r controller.Runtime, cache *revocation.Cache) (string, error) {
+	securityState, err := safe.ReaderGetByID[*runtimeres.SecurityState](ctx, r, runtimeres.SecurityStateID)
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("failed to get SecurityState: %w", err)
+	}
+
+	spec := securityState.TypedSpec()
+	if len(spec.CertChain) < 2 {
+		// no chain (e.g. bare TPM key) means nothing to check revocation of
+		return "", nil
+	}
+
+	leaf, issuer := spec.CertChain[0], spec.CertChain[1]
+
+	leafCert, err := certInfoToX509(leaf)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse PCR signing leaf certificate: %w", err)
+	}
+
+	issuerCert, err := certInfoToX509(issuer)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse PCR signing issuer certificate: %w", err)
+	}
+
+	result, ok := cache.Get(leaf.Fingerprint)
+	if !ok || time.Now().After(result.NextUpdate) {
+		checked, err := revocation.Check(ctx, http.DefaultClient, leafCert, issuerCert)
+		if err != nil {
+			return "", err
+		}
+
+		result = checked
+
+		if err := cache.Put(leaf.Fingerprint, result); err != nil {
+			return "", fmt.Errorf("failed to cache revocation result: %w", err)
+		}
+	}
+
+	newState := string(result.Status)
+
+	return newState, safe.WriterModify(ctx, r, runtimeres.NewSecurityStateSpec(runtimeres.NamespaceName), func(s *runtimeres.SecurityState) error {
+		s.TypedSpec().RevocationState = newState
+
+		return nil
+	})
+}
+
+// certInfoToX509 reparses the *x509.Certificate stored in CertInfo.Raw.
+// OCSP request/response handling needs the real ASN.1 structure and public
+// key of both the leaf and issuer, which a hand-rebuilt certificate can't
+// provide.
+func certInfoToX509(info runtimeres.CertInfo) (*x509.Certificate, error) {
+	return x509.ParseCertificate(info.Raw)
+}
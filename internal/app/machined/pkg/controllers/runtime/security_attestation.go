@@ -0,0 +1,327 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cosi-project/runtime/pkg/controller"
+	"github.com/cosi-project/runtime/pkg/safe"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/siderolabs/gen/optional"
+	"go.uber.org/zap"
+
+	"github.com/siderolabs/talos/pkg/attestation"
+	"github.com/siderolabs/talos/pkg/kms/tpm"
+	runtimeres "github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+	"github.com/siderolabs/talos/pkg/pcr"
+)
+
+// kernelCmdlinePath is where the kernel command line the running node
+// booted with can be read from.
+const kernelCmdlinePath = "/proc/cmdline"
+
+// DefaultOfflineAttestationDir is where the DSSE envelope is parked when no
+// transparency log is reachable at boot.
+const DefaultOfflineAttestationDir = "/var/lib/talos/attestations"
+
+// offlineRetryInterval is how often SecurityAttestationController retries
+// submitting a previously offline attestation.
+const offlineRetryInterval = 5 * time.Minute
+
+// SecurityAttestationController publishes a signed DSSE attestation of
+// SecurityState to a transparency log once SecurityState has stabilized,
+// and keeps retrying in the background when it has to fall back to the
+// offline path.
+type SecurityAttestationController struct {
+	// RekorURL is the transparency log endpoint. Left unset (the zero
+	// value) disables the controller, since most Talos clusters don't run
+	// a transparency log today.
+	RekorURL string
+
+	// OfflineDir overrides DefaultOfflineAttestationDir, mainly for tests.
+	OfflineDir string
+}
+
+// Name implements controller.Controller interface.
+func (ctrl *SecurityAttestationController) Name() string {
+	return "runtime.SecurityAttestationController"
+}
+
+// Inputs implements controller.Controller interface.
+func (ctrl *SecurityAttestationController) Inputs() []controller.Input {
+	return []controller.Input{
+		{
+			Namespace: runtimeres.NamespaceName,
+			Type:      runtimeres.SecurityStateType,
+			ID:        optional.Some(runtimeres.SecurityStateID),
+			Kind:      controller.InputWeak,
+		},
+		{
+			Namespace: runtimeres.NamespaceName,
+			Type:      runtimeres.PCRSigningKeysConfigType,
+			ID:        optional.Some(runtimeres.PCRSigningKeysConfigID),
+			Kind:      controller.InputWeak,
+		},
+	}
+}
+
+// Outputs implements controller.Controller interface.
+func (ctrl *SecurityAttestationController) Outputs() []controller.Output {
+	return []controller.Output{
+		{
+			Type: runtimeres.SecurityAttestationType,
+			Kind: controller.OutputExclusive,
+		},
+	}
+}
+
+// Run implements controller.Controller interface.
+func (ctrl *SecurityAttestationController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
+	if ctrl.RekorURL == "" {
+		// no transparency log configured, nothing to do
+		return nil
+	}
+
+	offlineDir := ctrl.OfflineDir
+	if offlineDir == "" {
+		offlineDir = DefaultOfflineAttestationDir
+	}
+
+	ticker := time.NewTicker(offlineRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+			if err := ctrl.attestOnce(ctx, r, logger, offlineDir); err != nil {
+				logger.Warn("failed to publish security attestation", zap.Error(err))
+			}
+		case <-ticker.C:
+			if err := ctrl.retryOffline(ctx, r, logger, offlineDir); err != nil {
+				logger.Warn("failed to retry offline security attestation", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (ctrl *SecurityAttestationController) attestOnce(ctx context.Context, r controller.Runtime, logger *zap.Logger, offlineDir string) error {
+	securityState, err := safe.ReaderGetByID[*runtimeres.SecurityState](ctx, r, runtimeres.SecurityStateID)
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get SecurityState: %w", err)
+	}
+
+	spec := securityState.TypedSpec()
+	if spec.PCRSigningKeyURI == "" {
+		// nothing meaningful to attest to yet
+		return nil
+	}
+
+	kernelCmdlineHash, err := kernelCmdlineHash()
+	if err != nil {
+		logger.Warn("failed to hash kernel cmdline for attestation", zap.Error(err))
+	}
+
+	pcrValues, err := tpm.ReadPCRValues(ctx)
+	if err != nil {
+		logger.Warn("failed to read PCR values for attestation", zap.Error(err))
+	}
+
+	signingURIs, quorumKeys, threshold, err := ctrl.signingKeys(ctx, r, spec.PCRSigningKeyURI)
+	if err != nil {
+		return fmt.Errorf("failed to resolve PCR signing keys for attestation: %w", err)
+	}
+
+	nodeName, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to resolve node name for attestation: %w", err)
+	}
+
+	envelope, err := attestation.Build(ctx, nodeName, signingURIs, attestation.Predicate{
+		SecureBoot:               spec.SecureBoot,
+		PCRSigningKeyFingerprint: spec.PCRSigningKeyFingerprint,
+		PCRSigningKeyURI:         spec.PCRSigningKeyURI,
+		KernelCmdlineHash:        kernelCmdlineHash,
+		PCRValues:                pcrValues,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build attestation: %w", err)
+	}
+
+	quorumMet, err := attestation.VerifyQuorum(ctx, envelope, quorumKeys, threshold)
+	if err != nil {
+		return fmt.Errorf("failed to self-verify attestation quorum: %w", err)
+	}
+
+	if !quorumMet {
+		return fmt.Errorf("attestation does not satisfy the configured signing quorum (threshold %d)", threshold)
+	}
+
+	dsseDigest, err := attestation.Digest(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to digest attestation envelope: %w", err)
+	}
+
+	entry, submitErr := attestation.Submit(ctx, ctrl.RekorURL, envelope)
+	if submitErr != nil {
+		logger.Warn("transparency log unreachable, deferring attestation", zap.Error(submitErr))
+
+		path, writeErr := attestation.WriteOffline(offlineDir, envelope)
+		if writeErr != nil {
+			return fmt.Errorf("failed to persist offline attestation: %w", writeErr)
+		}
+
+		return ctrl.updateAttestation(ctx, r, func(a *runtimeres.SecurityAttestationSpecProto) {
+			a.DSSEDigest = dsseDigest
+			a.Offline = true
+			a.OfflinePath = path
+		})
+	}
+
+	return ctrl.updateAttestation(ctx, r, func(a *runtimeres.SecurityAttestationSpecProto) {
+		a.UUID = entry.UUID
+		a.LogIndex = entry.LogIndex
+		a.InclusionProof = entry.InclusionProof
+		a.DSSEDigest = dsseDigest
+		a.Offline = false
+	})
+}
+
+// signingKeys resolves the set of URIs to sign the attestation with and the
+// pcr.Key candidates and threshold to self-verify it against, from
+// PCRSigningKeysConfig. Falls back to a single key (fallbackURI, threshold
+// 1) when no PCRSigningKeysConfig has been published yet.
+func (ctrl *SecurityAttestationController) signingKeys(ctx context.Context, r controller.Runtime, fallbackURI string) ([]string, []pcr.Key, int, error) {
+	keysConfig, err := safe.ReaderGetByID[*runtimeres.PCRSigningKeysConfig](ctx, r, runtimeres.PCRSigningKeysConfigID)
+	if err != nil {
+		if state.IsNotFoundError(err) {
+			return []string{fallbackURI}, []pcr.Key{{URI: fallbackURI, Role: runtimeres.PCRSigningKeyRoleCurrent}}, 1, nil
+		}
+
+		return nil, nil, 0, fmt.Errorf("failed to get PCRSigningKeysConfig: %w", err)
+	}
+
+	entries := keysConfig.TypedSpec().Keys
+	if len(entries) == 0 {
+		return []string{fallbackURI}, []pcr.Key{{URI: fallbackURI, Role: runtimeres.PCRSigningKeyRoleCurrent}}, 1, nil
+	}
+
+	var signingURIs []string
+
+	quorumKeys := make([]pcr.Key, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.Role == runtimeres.PCRSigningKeyRoleCurrent {
+			signingURIs = append(signingURIs, entry.URI)
+		}
+
+		quorumKeys = append(quorumKeys, pcr.Key{
+			URI:       entry.URI,
+			Role:      entry.Role,
+			NotBefore: unixSecondsOrZero(entry.NotBefore),
+			NotAfter:  unixSecondsOrZero(entry.NotAfter),
+		})
+	}
+
+	if len(signingURIs) == 0 {
+		signingURIs = []string{fallbackURI}
+	}
+
+	threshold := int(keysConfig.TypedSpec().QuorumThreshold)
+	if threshold <= 0 {
+		threshold = 1
+	}
+
+	return signingURIs, quorumKeys, threshold, nil
+}
+
+// unixSecondsOrZero converts Unix seconds to time.Time, preserving the
+// "unbounded" meaning of zero instead of mapping it to the 1970 epoch.
+func unixSecondsOrZero(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(sec, 0)
+}
+
+// kernelCmdlineHash returns the hex-encoded SHA-256 digest of the kernel
+// command line the node booted with.
+func kernelCmdlineHash() (string, error) {
+	cmdline, err := os.ReadFile(kernelCmdlinePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", kernelCmdlinePath, err)
+	}
+
+	sum := sha256.Sum256(cmdline)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (ctrl *SecurityAttestationController) retryOffline(ctx context.Context, r controller.Runtime, logger *zap.Logger, offlineDir string) error {
+	path := offlineDir + "/security-attestation.dsse.json"
+
+	if _, err := os.Stat(path); err != nil {
+		return nil // nothing pending
+	}
+
+	envelope, entry, err := attestation.ReadOffline(path)
+	if err != nil {
+		return err
+	}
+
+	if entry != nil {
+		// already submitted by a previous retry; nothing left to resubmit.
+		return nil
+	}
+
+	entry, err = attestation.Submit(ctx, ctrl.RekorURL, envelope)
+	if err != nil {
+		logger.Debug("transparency log still unreachable", zap.Error(err))
+
+		return nil
+	}
+
+	if err := attestation.MarkSubmitted(path, entry); err != nil {
+		return fmt.Errorf("failed to mark offline attestation as submitted: %w", err)
+	}
+
+	dsseDigest, err := attestation.Digest(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to digest attestation envelope: %w", err)
+	}
+
+	return ctrl.updateAttestation(ctx, r, func(a *runtimeres.SecurityAttestationSpecProto) {
+		if a.DSSEDigest != dsseDigest {
+			// the resource has moved on to a newer attestation since this
+			// one went offline; leave it alone.
+			return
+		}
+
+		a.UUID = entry.UUID
+		a.LogIndex = entry.LogIndex
+		a.InclusionProof = entry.InclusionProof
+		a.Offline = false
+	})
+}
+
+func (ctrl *SecurityAttestationController) updateAttestation(ctx context.Context, r controller.Runtime, modify func(*runtimeres.SecurityAttestationSpecProto)) error {
+	return safe.WriterModify(ctx, r, runtimeres.NewSecurityAttestationSpec(runtimeres.NamespaceName), func(a *runtimeres.SecurityAttestation) error {
+		modify(a.TypedSpec())
+
+		return nil
+	})
+}
@@ -10,16 +10,18 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/hex"
-	"encoding/pem"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/cosi-project/runtime/pkg/controller"
 	"github.com/cosi-project/runtime/pkg/safe"
+	"github.com/cosi-project/runtime/pkg/state"
 	"github.com/foxboron/go-uefi/efi"
+	"github.com/siderolabs/gen/optional"
 	"go.uber.org/zap"
 
+	"github.com/siderolabs/talos/pkg/kms"
+	_ "github.com/siderolabs/talos/pkg/kms/pem" // register the "pem" KeyManager backend
 	"github.com/siderolabs/talos/pkg/machinery/constants"
 	runtimeres "github.com/siderolabs/talos/pkg/machinery/resources/runtime"
 )
@@ -34,63 +36,209 @@ func (ctrl *SecurityStateController) Name() string {
 
 // Inputs implements controller.Controller interface.
 func (ctrl *SecurityStateController) Inputs() []controller.Input {
-	return nil
+	return []controller.Input{
+		{
+			Namespace: runtimeres.NamespaceName,
+			Type:      runtimeres.PCRSigningKeysConfigType,
+			ID:        optional.Some(runtimeres.PCRSigningKeysConfigID),
+			Kind:      controller.InputWeak,
+		},
+	}
 }
 
 // Outputs implements controller.Controller interface.
 func (ctrl *SecurityStateController) Outputs() []controller.Output {
 	return []controller.Output{
 		{
+			// Shared, since RevocationStateController also writes to this
+			// resource (the RevocationState field) on its own schedule.
 			Type: runtimeres.SecurityStateType,
-			Kind: controller.OutputExclusive,
+			Kind: controller.OutputShared,
 		},
 	}
 }
 
 // Run implements controller.Controller interface.
+//
+// Unlike before key rotation support was added, this controller no longer
+// terminates after its first run: PCRSigningKeysConfig can change any time
+// an operator rotates keys, and SecurityState needs to reflect that without
+// requiring a reboot.
 // nolint:gocyclo
 func (ctrl *SecurityStateController) Run(ctx context.Context, r controller.Runtime, logger *zap.Logger) error {
-	select {
-	case <-ctx.Done():
-		return nil
-	case <-r.EventCh():
-	}
+	var secureBootReported bool
 
-	var secureBootState bool
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.EventCh():
+		}
 
-	if efi.GetSecureBoot() && !efi.GetSetupMode() {
-		secureBootState = true
-	}
+		if !secureBootReported {
+			var secureBootState bool
 
-	if err := safe.WriterModify(ctx, r, runtimeres.NewSecurityStateSpec(runtimeres.NamespaceName), func(state *runtimeres.SecurityState) error {
-		state.TypedSpec().SecureBoot = secureBootState
+			if efi.GetSecureBoot() && !efi.GetSetupMode() {
+				secureBootState = true
+			}
 
-		return nil
-	}); err != nil {
-		return err
-	}
+			if err := safe.WriterModify(ctx, r, runtimeres.NewSecurityStateSpec(runtimeres.NamespaceName), func(securityState *runtimeres.SecurityState) error {
+				securityState.TypedSpec().SecureBoot = secureBootState
+
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			secureBootReported = true
+		}
+
+		keysConfig, err := safe.ReaderGetByID[*runtimeres.PCRSigningKeysConfig](ctx, r, runtimeres.PCRSigningKeysConfigID)
+		if err != nil {
+			if state.IsNotFoundError(err) {
+				continue
+			}
 
-	if pcrPublicKeyData, err := os.ReadFile(constants.PCRPublicKey); err == nil {
-		block, _ := pem.Decode(pcrPublicKeyData)
-		if block == nil {
-			return fmt.Errorf("failed to decode PEM block for PCR public key")
+			return fmt.Errorf("failed to get PCRSigningKeysConfig: %w", err)
 		}
 
-		cert := x509.Certificate{
-			Raw: block.Bytes,
+		entries := keysConfig.TypedSpec().Keys
+		if len(entries) == 0 {
+			entries = []runtimeres.PCRSigningKeyConfigEntry{
+				{URI: "pem:" + constants.PCRPublicKey, Role: runtimeres.PCRSigningKeyRoleCurrent},
+			}
 		}
 
-		if err := safe.WriterModify(ctx, r, runtimeres.NewSecurityStateSpec(runtimeres.NamespaceName), func(state *runtimeres.SecurityState) error {
-			state.TypedSpec().PCRSigningKeyFingerprint = x509CertFingerprint(cert)
+		keys := make([]runtimeres.PCRSigningKeyInfo, 0, len(entries))
+
+		var currentURI, currentFingerprint string
+
+		var currentChain []runtimeres.CertInfo
+
+		var currentTrustAnchor string
+
+		for _, entry := range entries {
+			fingerprint, err := ctrl.pcrSigningKeyFingerprint(ctx, entry.URI)
+			if err != nil {
+				logger.Warn("failed to resolve PCR signing key", zap.String("uri", entry.URI), zap.String("role", entry.Role), zap.Error(err))
+
+				continue
+			}
+
+			keys = append(keys, runtimeres.PCRSigningKeyInfo{
+				Fingerprint: fingerprint,
+				URI:         entry.URI,
+				Role:        entry.Role,
+				NotBefore:   entry.NotBefore,
+				NotAfter:    entry.NotAfter,
+			})
+
+			if entry.Role == runtimeres.PCRSigningKeyRoleCurrent {
+				currentURI, currentFingerprint = entry.URI, fingerprint
+				currentChain, currentTrustAnchor = ctrl.pcrSigningKeyChain(ctx, entry.URI, logger)
+			}
+		}
+
+		if err := safe.WriterModify(ctx, r, runtimeres.NewSecurityStateSpec(runtimeres.NamespaceName), func(securityState *runtimeres.SecurityState) error {
+			securityState.TypedSpec().PCRSigningKeys = keys
+			securityState.TypedSpec().PCRSigningKeyFingerprint = currentFingerprint
+			securityState.TypedSpec().PCRSigningKeyURI = currentURI
+			securityState.TypedSpec().CertChain = currentChain
+			securityState.TypedSpec().TrustAnchorFingerprint = currentTrustAnchor
 
 			return nil
 		}); err != nil {
 			return err
 		}
 	}
+}
+
+// pcrSigningKeyFingerprint resolves keyURI via the pkg/kms registry and
+// returns the SHA-256 fingerprint of its certificate (falling back to the
+// fingerprint of the bare public key when the backend has no certificate).
+func (ctrl *SecurityStateController) pcrSigningKeyFingerprint(ctx context.Context, keyURI string) (string, error) {
+	manager, err := kms.Get(ctx, keyURI)
+	if err != nil {
+		return "", err
+	}
+
+	defer manager.Close() //nolint:errcheck
+
+	if der, err := manager.Certificate(ctx, keyURI); err == nil {
+		return x509CertFingerprint(x509.Certificate{Raw: der}), nil
+	} else if err != kms.ErrNoCertificate {
+		return "", err
+	}
+
+	pub, err := manager.PublicKey(ctx, keyURI)
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal PCR signing public key: %w", err)
+	}
+
+	return x509CertFingerprint(x509.Certificate{Raw: der}), nil
+}
+
+// pcrSigningKeyChain returns the parsed certificate chain for keyURI, leaf
+// first, and the fingerprint of its trust anchor (the last certificate in
+// the chain). It returns a nil chain when the backend does not implement
+// kms.ChainProvider (e.g. TPM/PKCS#11 handles without an accompanying
+// certificate), which is not an error.
+func (ctrl *SecurityStateController) pcrSigningKeyChain(ctx context.Context, keyURI string, logger *zap.Logger) ([]runtimeres.CertInfo, string) {
+	manager, err := kms.Get(ctx, keyURI)
+	if err != nil {
+		return nil, ""
+	}
+
+	defer manager.Close() //nolint:errcheck
+
+	provider, ok := manager.(kms.ChainProvider)
+	if !ok {
+		return nil, ""
+	}
+
+	rawChain, err := provider.CertificateChain(ctx, keyURI)
+	if err != nil {
+		logger.Debug("failed to read PCR signing certificate chain", zap.Error(err))
+
+		return nil, ""
+	}
+
+	chain := make([]runtimeres.CertInfo, 0, len(rawChain))
+
+	for _, der := range rawChain {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			logger.Warn("failed to parse certificate in PCR signing chain", zap.Error(err))
+
+			continue
+		}
+
+		chain = append(chain, runtimeres.CertInfo{
+			Subject:               cert.Subject.String(),
+			Issuer:                cert.Issuer.String(),
+			NotBefore:             cert.NotBefore.Unix(),
+			NotAfter:              cert.NotAfter.Unix(),
+			SKI:                   hex.EncodeToString(cert.SubjectKeyId),
+			AKI:                   hex.EncodeToString(cert.AuthorityKeyId),
+			SANs:                  cert.DNSNames,
+			Fingerprint:           x509CertFingerprint(*cert),
+			SerialNumber:          cert.SerialNumber.String(),
+			OCSPServer:            cert.OCSPServer,
+			CRLDistributionPoints: cert.CRLDistributionPoints,
+			Raw:                   cert.Raw,
+		})
+	}
+
+	if len(chain) == 0 {
+		return nil, ""
+	}
 
-	// terminating the controller here, as we need to only populate securitystate once
-	return nil
+	return chain, chain[len(chain)-1].Fingerprint
 }
 
 func x509CertFingerprint(cert x509.Certificate) string {
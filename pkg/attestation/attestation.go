@@ -0,0 +1,198 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package attestation builds in-toto statements describing a node's
+// SecurityState, wraps them in a DSSE envelope signed via pkg/kms, and
+// submits the envelope to a Rekor-compatible transparency log.
+package attestation
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/siderolabs/talos/pkg/kms"
+	"github.com/siderolabs/talos/pkg/pcr"
+)
+
+// PredicateType identifies the Talos-specific in-toto predicate produced here.
+const PredicateType = "https://talos.dev/attestation/security-state/v1"
+
+// StatementType is the in-toto statement type, per the in-toto v1 spec.
+const StatementType = "https://in-toto.io/Statement/v1"
+
+// Predicate is the Talos-specific payload of the in-toto statement: the
+// subset of SecurityState worth attesting to.
+type Predicate struct {
+	SecureBoot               bool              `json:"secureBoot"`
+	PCRSigningKeyFingerprint string            `json:"pcrSigningKeyFingerprint"`
+	PCRSigningKeyURI         string            `json:"pcrSigningKeyURI"`
+	KernelCmdlineHash        string            `json:"kernelCmdlineHash"`
+	PCRValues                map[string]string `json:"pcrValues"`
+}
+
+// Subject is the in-toto statement subject: the node being attested to.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Statement is an in-toto v1 statement.
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+// Envelope is a DSSE envelope (https://github.com/secure-systems-lab/dsse),
+// wrapping a serialized Statement.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"` // base64-encoded Statement
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is a single DSSE signature entry.
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"` // base64-encoded signature
+}
+
+// dssePayloadType is the PAE payload type for in-toto statements.
+const dssePayloadType = "application/vnd.in-toto+json"
+
+// Build constructs a DSSE envelope wrapping an in-toto statement for
+// nodeName, signed once by each KeyManager addressed by keyURIs. A single
+// URI produces a conventional single-signature envelope; multiple URIs
+// produce a multi-signature envelope so that VerifyQuorum can later require
+// N-of-M of them to agree.
+//
+// Every keyURI's backend must implement kms.Signer; the "pem" backend does
+// not, since Talos never keeps PCR signing private keys on disk.
+func Build(ctx context.Context, nodeName string, keyURIs []string, predicate Predicate) (*Envelope, error) {
+	if len(keyURIs) == 0 {
+		return nil, fmt.Errorf("attestation: no signing keys provided")
+	}
+
+	statement := Statement{
+		Type:          StatementType,
+		PredicateType: PredicateType,
+		Predicate:     predicate,
+		Subject: []Subject{
+			{
+				Name:   nodeName,
+				Digest: map[string]string{"sha256": sha256Hex([]byte(predicate.KernelCmdlineHash))},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, fmt.Errorf("attestation: failed to marshal statement: %w", err)
+	}
+
+	digest := sha256Sum(preAuthEncode(dssePayloadType, payload))
+
+	signatures := make([]Signature, 0, len(keyURIs))
+
+	for _, keyURI := range keyURIs {
+		sig, err := sign(ctx, keyURI, digest)
+		if err != nil {
+			return nil, err
+		}
+
+		signatures = append(signatures, Signature{
+			KeyID: keyURI,
+			Sig:   base64.StdEncoding.EncodeToString(sig),
+		})
+	}
+
+	return &Envelope{
+		PayloadType: dssePayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  signatures,
+	}, nil
+}
+
+// sign produces a signature over digest using the KeyManager addressed by
+// keyURI.
+func sign(ctx context.Context, keyURI string, digest []byte) ([]byte, error) {
+	manager, err := kms.Get(ctx, keyURI)
+	if err != nil {
+		return nil, fmt.Errorf("attestation: failed to resolve signing key %q: %w", keyURI, err)
+	}
+
+	defer manager.Close() //nolint:errcheck
+
+	signer, ok := manager.(kms.Signer)
+	if !ok {
+		return nil, fmt.Errorf("attestation: backend %q cannot sign attestations", manager.Name())
+	}
+
+	sig, err := signer.Sign(ctx, keyURI, digest, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("attestation: failed to sign statement: %w", err)
+	}
+
+	return sig, nil
+}
+
+// VerifyQuorum reports whether at least threshold of envelope's signatures
+// verify under distinct, non-revoked keys in keys. It ties pkg/pcr's
+// verification logic to the DSSE envelopes this package produces, so that
+// PCRSigningKeysConfig's QuorumThreshold actually gates acceptance of an
+// attestation rather than being recorded and never checked.
+func VerifyQuorum(ctx context.Context, envelope *Envelope, keys []pcr.Key, threshold int) (bool, error) {
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return false, fmt.Errorf("attestation: failed to decode envelope payload: %w", err)
+	}
+
+	digest := sha256Sum(preAuthEncode(envelope.PayloadType, payload))
+
+	sigs := make([][]byte, 0, len(envelope.Signatures))
+
+	for _, signature := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			return false, fmt.Errorf("attestation: failed to decode signature from %q: %w", signature.KeyID, err)
+		}
+
+		sigs = append(sigs, sig)
+	}
+
+	return pcr.VerifyQuorum(ctx, keys, digest, sigs, threshold)
+}
+
+// Digest returns the hex-encoded SHA-256 digest of envelope's canonical
+// JSON encoding, so a later successful submission of a previously-offline
+// envelope can be matched back to the SecurityAttestation it covers.
+func Digest(envelope *Envelope) (string, error) {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return "", fmt.Errorf("attestation: failed to marshal envelope: %w", err)
+	}
+
+	return sha256Hex(data), nil
+}
+
+// preAuthEncode implements the DSSE PAE(type, body) encoding that is
+// actually signed, per the DSSE spec.
+func preAuthEncode(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+func sha256Sum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+
+	return sum[:]
+}
+
+func sha256Hex(data []byte) string {
+	return fmt.Sprintf("%x", sha256Sum(data))
+}
@@ -0,0 +1,183 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package attestation_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/talos/pkg/attestation"
+	"github.com/siderolabs/talos/pkg/kms"
+	runtimeres "github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+	"github.com/siderolabs/talos/pkg/pcr"
+)
+
+type fakeSigner struct {
+	pub  ed25519.PublicKey
+	priv ed25519.PrivateKey
+}
+
+func (f *fakeSigner) Name() string { return "attestation-test" }
+
+func (f *fakeSigner) PublicKey(context.Context, string) (crypto.PublicKey, error) { return f.pub, nil }
+
+func (f *fakeSigner) Certificate(context.Context, string) ([]byte, error) { return nil, kms.ErrNoCertificate }
+
+func (f *fakeSigner) Close() error { return nil }
+
+func (f *fakeSigner) Sign(_ context.Context, _ string, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return ed25519.Sign(f.priv, digest), nil
+}
+
+// fakeSignerSeq keeps schemes unique when one test registers several signers.
+var fakeSignerSeq int
+
+func registerFakeSigner(t *testing.T) (string, *fakeSigner) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer := &fakeSigner{pub: pub, priv: priv}
+
+	fakeSignerSeq++
+
+	scheme := fmt.Sprintf("attestation-test-%s-%d", t.Name(), fakeSignerSeq)
+
+	kms.Register(scheme, func(context.Context, kms.Options) (kms.KeyManager, error) {
+		return signer, nil
+	})
+
+	return scheme + ":key", signer
+}
+
+func TestBuildProducesVerifiableEnvelope(t *testing.T) {
+	keyURI, signer := registerFakeSigner(t)
+
+	envelope, err := attestation.Build(context.Background(), "node1", []string{keyURI}, attestation.Predicate{
+		SecureBoot:               true,
+		PCRSigningKeyFingerprint: "AB:CD",
+		PCRSigningKeyURI:         "pem:/etc/key.pem",
+		KernelCmdlineHash:        "deadbeef",
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "application/vnd.in-toto+json", envelope.PayloadType)
+	require.Len(t, envelope.Signatures, 1)
+	require.Equal(t, keyURI, envelope.Signatures[0].KeyID)
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	require.NoError(t, err)
+
+	var statement attestation.Statement
+	require.NoError(t, json.Unmarshal(payload, &statement))
+	require.Equal(t, attestation.StatementType, statement.Type)
+	require.Equal(t, attestation.PredicateType, statement.PredicateType)
+	require.True(t, statement.Predicate.SecureBoot)
+
+	sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+	require.NoError(t, err)
+
+	pae := []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(envelope.PayloadType), envelope.PayloadType, len(payload), payload))
+	sum := sha256.Sum256(pae)
+	require.True(t, ed25519.Verify(signer.pub, sum[:], sig))
+}
+
+func TestDigestIsDeterministic(t *testing.T) {
+	envelope := &attestation.Envelope{
+		PayloadType: "application/vnd.in-toto+json",
+		Payload:     "cGF5bG9hZA==",
+		Signatures: []attestation.Signature{
+			{KeyID: "pem:/etc/key.pem", Sig: "c2ln"},
+		},
+	}
+
+	d1, err := attestation.Digest(envelope)
+	require.NoError(t, err)
+
+	d2, err := attestation.Digest(envelope)
+	require.NoError(t, err)
+
+	require.Equal(t, d1, d2)
+	require.NotEmpty(t, d1)
+}
+
+func TestBuildRejectsNonSigningBackend(t *testing.T) {
+	scheme := "attestation-nonsigner-" + t.Name()
+
+	kms.Register(scheme, func(context.Context, kms.Options) (kms.KeyManager, error) {
+		return &nonSigningManager{}, nil
+	})
+
+	_, err := attestation.Build(context.Background(), "node1", []string{scheme + ":key"}, attestation.Predicate{})
+	require.Error(t, err)
+}
+
+func TestBuildRejectsNoKeys(t *testing.T) {
+	_, err := attestation.Build(context.Background(), "node1", nil, attestation.Predicate{})
+	require.Error(t, err)
+}
+
+func TestVerifyQuorumRequiresConfiguredThreshold(t *testing.T) {
+	keyURI1, _ := registerFakeSigner(t)
+	keyURI2, _ := registerFakeSigner(t)
+
+	envelope, err := attestation.Build(context.Background(), "node1", []string{keyURI1, keyURI2}, attestation.Predicate{
+		KernelCmdlineHash: "deadbeef",
+	})
+	require.NoError(t, err)
+	require.Len(t, envelope.Signatures, 2)
+
+	keys := []pcr.Key{
+		{URI: keyURI1, Role: runtimeres.PCRSigningKeyRoleCurrent},
+		{URI: keyURI2, Role: runtimeres.PCRSigningKeyRoleCurrent},
+	}
+
+	ok, err := attestation.VerifyQuorum(context.Background(), envelope, keys, 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = attestation.VerifyQuorum(context.Background(), envelope, keys, 3)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestVerifyQuorumIgnoresRevokedKey(t *testing.T) {
+	keyURI1, _ := registerFakeSigner(t)
+	keyURI2, _ := registerFakeSigner(t)
+
+	envelope, err := attestation.Build(context.Background(), "node1", []string{keyURI1}, attestation.Predicate{
+		KernelCmdlineHash: "deadbeef",
+	})
+	require.NoError(t, err)
+
+	keys := []pcr.Key{
+		{URI: keyURI1, Role: runtimeres.PCRSigningKeyRoleRevoked},
+		{URI: keyURI2, Role: runtimeres.PCRSigningKeyRoleCurrent},
+	}
+
+	ok, err := attestation.VerifyQuorum(context.Background(), envelope, keys, 1)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+type nonSigningManager struct{}
+
+func (*nonSigningManager) Name() string { return "nonsigner" }
+
+func (*nonSigningManager) PublicKey(context.Context, string) (crypto.PublicKey, error) { return nil, nil }
+
+func (*nonSigningManager) Certificate(context.Context, string) ([]byte, error) { return nil, kms.ErrNoCertificate }
+
+func (*nonSigningManager) Close() error { return nil }
@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package attestation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// offlineRecord is what WriteOffline/ReadOffline persist: the envelope plus
+// whatever log entry (if any) has been resolved for it so far.
+type offlineRecord struct {
+	Envelope *Envelope `json:"envelope"`
+	Entry    *LogEntry `json:"entry,omitempty"`
+}
+
+// WriteOffline persists envelope to dir for later submission, used when no
+// network is available at boot. dir is created if it does not exist.
+func WriteOffline(dir string, envelope *Envelope) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("attestation: failed to create offline dir %q: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, "security-attestation.dsse.json")
+
+	data, err := json.Marshal(offlineRecord{Envelope: envelope})
+	if err != nil {
+		return "", fmt.Errorf("attestation: failed to marshal offline record: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("attestation: failed to write offline record to %q: %w", path, err)
+	}
+
+	return path, nil
+}
+
+// ReadOffline loads a previously written offline record from path, along
+// with the log entry it was submitted as if MarkSubmitted has already run
+// for it. A caller checking whether a record is still pending must look at
+// the returned entry, not just whether the file exists - the file stays on
+// disk (for the eventual Digest match-back) even after a successful
+// submission.
+func ReadOffline(path string) (*Envelope, *LogEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("attestation: failed to read offline record %q: %w", path, err)
+	}
+
+	var record offlineRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, nil, fmt.Errorf("attestation: failed to unmarshal offline record %q: %w", path, err)
+	}
+
+	return record.Envelope, record.Entry, nil
+}
+
+// MarkSubmitted updates an offline record at path with the log entry it was
+// eventually submitted as, so that retries are idempotent.
+func MarkSubmitted(path string, entry *LogEntry) error {
+	envelope, _, err := ReadOffline(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(offlineRecord{Envelope: envelope, Entry: entry})
+	if err != nil {
+		return fmt.Errorf("attestation: failed to marshal offline record: %w", err)
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
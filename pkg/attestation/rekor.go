@@ -0,0 +1,145 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package attestation
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+// LogEntry is the subset of a Rekor response that SecurityAttestation
+// records.
+type LogEntry struct {
+	UUID           string
+	LogIndex       int64
+	InclusionProof string // base64-encoded JSON-marshaled InclusionProof, opaque to callers
+}
+
+// InclusionProof is the full Merkle audit path Rekor returns for a log
+// entry, everything a caller needs to independently verify inclusion
+// against the log's signed tree head: the leaf's position, the tree size
+// and root hash the proof is relative to, and the hashes making up the
+// audit path.
+type InclusionProof struct {
+	LogIndex int64    `json:"logIndex"`
+	RootHash string   `json:"rootHash"` // base64-encoded
+	TreeSize int64    `json:"treeSize"`
+	Hashes   []string `json:"hashes"` // base64-encoded, leaf to root
+}
+
+// Submit uploads envelope to the Rekor-compatible transparency log at
+// rekorURL and returns the resulting log entry.
+func Submit(ctx context.Context, rekorURL string, envelope *Envelope) (*LogEntry, error) {
+	rekorClient, err := client.GetRekorClient(rekorURL, client.WithHTTPClient(http.DefaultClient))
+	if err != nil {
+		return nil, fmt.Errorf("attestation: failed to build rekor client for %q: %w", rekorURL, err)
+	}
+
+	content, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("attestation: failed to marshal envelope: %w", err)
+	}
+
+	proposed := models.Intoto{
+		APIVersion: stringPtr("0.0.2"),
+		Spec: models.IntotoV002Schema{
+			Content: &models.IntotoV002SchemaContent{
+				Envelope: base64.StdEncoding.EncodeToString(content),
+			},
+		},
+	}
+
+	params := entries.NewCreateLogEntryParamsWithContext(ctx)
+	params.SetProposedEntry(&proposed)
+
+	resp, err := rekorClient.Entries.CreateLogEntry(params)
+	if err != nil {
+		return nil, fmt.Errorf("attestation: failed to submit entry to %q: %w", rekorURL, err)
+	}
+
+	for uuid, entry := range resp.Payload {
+		var verification *models.InclusionProof
+		if entry.Verification != nil {
+			verification = entry.Verification.InclusionProof
+		}
+
+		proof, err := encodeInclusionProof(verification)
+		if err != nil {
+			return nil, fmt.Errorf("attestation: failed to encode inclusion proof for %q: %w", uuid, err)
+		}
+
+		return &LogEntry{
+			UUID:           uuid,
+			LogIndex:       *entry.LogIndex,
+			InclusionProof: proof,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("attestation: rekor returned no entries for submission to %q", rekorURL)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// encodeInclusionProof captures the full Merkle audit path off verification
+// (root hash, tree size, and every hash in the path, not just the first)
+// and serializes it as base64-encoded JSON for storage on SecurityAttestation.
+//
+// Rekor's InclusionProof reports Hashes and RootHash as hex-encoded
+// strings, not raw bytes, so each is hex-decoded before being re-encoded
+// as base64 to match InclusionProof's own documented field encoding.
+func encodeInclusionProof(verification *models.InclusionProof) (string, error) {
+	if verification == nil {
+		return "", nil
+	}
+
+	hashes := make([]string, len(verification.Hashes))
+
+	for i, h := range verification.Hashes {
+		raw, err := hex.DecodeString(h)
+		if err != nil {
+			return "", fmt.Errorf("attestation: failed to decode inclusion proof hash %d: %w", i, err)
+		}
+
+		hashes[i] = base64.StdEncoding.EncodeToString(raw)
+	}
+
+	proof := InclusionProof{
+		Hashes: hashes,
+	}
+
+	if verification.LogIndex != nil {
+		proof.LogIndex = *verification.LogIndex
+	}
+
+	if verification.TreeSize != nil {
+		proof.TreeSize = *verification.TreeSize
+	}
+
+	if verification.RootHash != nil {
+		raw, err := hex.DecodeString(*verification.RootHash)
+		if err != nil {
+			return "", fmt.Errorf("attestation: failed to decode inclusion proof root hash: %w", err)
+		}
+
+		proof.RootHash = base64.StdEncoding.EncodeToString(raw)
+	}
+
+	data, err := json.Marshal(proof)
+	if err != nil {
+		return "", fmt.Errorf("attestation: failed to marshal inclusion proof: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,88 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package attestation
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeInclusionProofNilVerification(t *testing.T) {
+	proof, err := encodeInclusionProof(nil)
+	require.NoError(t, err)
+	require.Empty(t, proof)
+}
+
+func TestEncodeInclusionProofDecodesHexFields(t *testing.T) {
+	// Rekor reports Hashes and RootHash as hex strings, not raw bytes or
+	// base64 - encodeInclusionProof must hex-decode them before storing
+	// them as base64, not treat the hex text itself as the raw value.
+	hash1 := "aa" + hex.EncodeToString([]byte("leaf-hash-one"))
+	hash2 := "bb" + hex.EncodeToString([]byte("leaf-hash-two"))
+	rootHash := "cc" + hex.EncodeToString([]byte("root-hash"))
+	logIndex := int64(7)
+	treeSize := int64(128)
+
+	verification := &models.InclusionProof{
+		Hashes:   []string{hash1, hash2},
+		RootHash: &rootHash,
+		LogIndex: &logIndex,
+		TreeSize: &treeSize,
+	}
+
+	encoded, err := encodeInclusionProof(verification)
+	require.NoError(t, err)
+	require.NotEmpty(t, encoded)
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+
+	var proof InclusionProof
+	require.NoError(t, json.Unmarshal(data, &proof))
+
+	require.Equal(t, logIndex, proof.LogIndex)
+	require.Equal(t, treeSize, proof.TreeSize)
+
+	wantRootHash, err := hex.DecodeString(rootHash)
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString(wantRootHash), proof.RootHash)
+
+	require.Len(t, proof.Hashes, 2)
+
+	for i, h := range []string{hash1, hash2} {
+		want, err := hex.DecodeString(h)
+		require.NoError(t, err)
+		require.Equal(t, base64.StdEncoding.EncodeToString(want), proof.Hashes[i])
+	}
+}
+
+func TestInclusionProofRoundTrip(t *testing.T) {
+	proof := InclusionProof{
+		LogIndex: 42,
+		RootHash: base64.StdEncoding.EncodeToString([]byte("root")),
+		TreeSize: 100,
+		Hashes: []string{
+			base64.StdEncoding.EncodeToString([]byte("h1")),
+			base64.StdEncoding.EncodeToString([]byte("h2")),
+		},
+	}
+
+	data, err := json.Marshal(proof)
+	require.NoError(t, err)
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	decodedData, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+
+	var decoded InclusionProof
+	require.NoError(t, json.Unmarshal(decodedData, &decoded))
+	require.Equal(t, proof, decoded)
+}
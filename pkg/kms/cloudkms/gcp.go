@@ -0,0 +1,91 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package cloudkms implements kms.KeyManager on top of GCP KMS, AWS KMS, and
+// Azure Key Vault, so that PCR signing keys can be kept in a cloud HSM
+// instead of on the node.
+package cloudkms
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	kmspb "cloud.google.com/go/kms/apiv1/kmspb"
+	gcpkms "cloud.google.com/go/kms/apiv1"
+
+	"github.com/siderolabs/talos/pkg/kms"
+)
+
+func init() {
+	kms.Register("gcpkms", func(ctx context.Context, opts kms.Options) (kms.KeyManager, error) {
+		client, err := gcpkms.NewKeyManagementClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("gcpkms: failed to create client: %w", err)
+		}
+
+		return &GCPKeyManager{client: client}, nil
+	})
+}
+
+// GCPKeyManager reads public keys out of GCP Cloud KMS.
+type GCPKeyManager struct {
+	client *gcpkms.KeyManagementClient
+}
+
+// Name implements kms.KeyManager.
+func (*GCPKeyManager) Name() string {
+	return "gcpkms"
+}
+
+// PublicKey implements kms.KeyManager.
+func (m *GCPKeyManager) PublicKey(ctx context.Context, uri string) (crypto.PublicKey, error) {
+	name := strings.TrimPrefix(uri, "gcpkms:")
+
+	resp, err := m.client.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("gcpkms: failed to fetch public key %q: %w", name, err)
+	}
+
+	block, _ := pemDecode(resp.GetPem())
+	if block == nil {
+		return nil, fmt.Errorf("gcpkms: failed to decode PEM response for %q", name)
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("gcpkms: failed to parse public key %q: %w", name, err)
+	}
+
+	return pub, nil
+}
+
+// Certificate implements kms.KeyManager.
+//
+// Cloud KMS keys are not wrapped in certificates.
+func (*GCPKeyManager) Certificate(context.Context, string) ([]byte, error) {
+	return nil, kms.ErrNoCertificate
+}
+
+// Close implements kms.KeyManager.
+func (m *GCPKeyManager) Close() error {
+	return m.client.Close()
+}
+
+// Sign implements kms.Signer using GCP KMS' AsymmetricSign.
+func (m *GCPKeyManager) Sign(ctx context.Context, uri string, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	name := strings.TrimPrefix(uri, "gcpkms:")
+
+	resp, err := m.client.AsymmetricSign(ctx, &kmspb.AsymmetricSignRequest{
+		Name:   name,
+		Digest: &kmspb.Digest{Digest: &kmspb.Digest_Sha256{Sha256: digest}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gcpkms: sign with %q failed: %w", name, err)
+	}
+
+	return resp.GetSignature(), nil
+}
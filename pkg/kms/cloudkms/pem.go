@@ -0,0 +1,20 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package cloudkms
+
+import "encoding/pem"
+
+// pemDecode is a tiny wrapper around encoding/pem.Decode that returns the
+// DER bytes of the first PEM block (nil if there is none) and the remaining
+// input. The GCP backend uses it to unwrap the PEM envelope GetPublicKey
+// returns.
+func pemDecode(data string) ([]byte, []byte) {
+	block, rest := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, rest
+	}
+
+	return block.Bytes, rest
+}
@@ -0,0 +1,117 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package kms provides a pluggable interface for retrieving the public half
+// of signing keys (PCR signing keys today, potentially others later) from a
+// variety of backends: on-disk PEM files, TPM2 persistent handles, PKCS#11
+// tokens, and cloud KMS providers.
+//
+// The shape of KeyManager intentionally mirrors smallstep's
+// kms.KeyManager/kmsapi: callers address key material by URI and never
+// handle private key bytes directly, which lets air-gapped or HSM-backed
+// deployments keep private keys off disk entirely.
+package kms
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// KeyManager is implemented by every supported key backend.
+type KeyManager interface {
+	// Name returns the backend name, e.g. "pem", "tpm", "pkcs11", "gcpkms", "awskms", "azurekeyvault".
+	Name() string
+
+	// PublicKey returns the public key addressed by uri.
+	PublicKey(ctx context.Context, uri string) (crypto.PublicKey, error)
+
+	// Certificate returns the DER-encoded certificate addressed by uri, if
+	// the backend stores one. Backends that only hold a bare public key
+	// (e.g. most TPM handles) return ErrNoCertificate.
+	Certificate(ctx context.Context, uri string) ([]byte, error)
+
+	// Close releases any resources (PKCS#11 sessions, TPM handles, cloud
+	// API clients, ...) held by the backend.
+	Close() error
+}
+
+// ErrNoCertificate is returned by KeyManager.Certificate when the backend
+// only has a bare public key for the given URI.
+var ErrNoCertificate = fmt.Errorf("kms: backend does not provide a certificate for this key")
+
+// ChainProvider is optionally implemented by backends that can return a
+// full certificate chain rather than just the leaf certificate, such as the
+// "pem" backend when pointed at a file containing intermediate and root
+// certificates in addition to the leaf.
+type ChainProvider interface {
+	// CertificateChain returns the DER-encoded certificate chain addressed
+	// by uri, leaf first.
+	CertificateChain(ctx context.Context, uri string) ([][]byte, error)
+}
+
+// Signer is optionally implemented by backends that can produce a signature
+// over a digest without ever exposing private key material, such as TPM2,
+// PKCS#11, and cloud KMS providers. The "pem" backend does not implement
+// it, since Talos does not keep PCR signing private keys on disk.
+type Signer interface {
+	// Sign returns a signature over digest, computed using the key
+	// addressed by uri. opts follows the conventions of crypto.Signer.
+	Sign(ctx context.Context, uri string, digest []byte, opts crypto.SignerOpts) ([]byte, error)
+}
+
+// Options carries the configuration needed to construct a KeyManager for a
+// single URI. Only the fields relevant to the selected backend are read.
+type Options struct {
+	// URI is the fully-qualified key URI, e.g. "tpm:0x81000001",
+	// "pkcs11:token=talos;object=pcr-signer", or
+	// "gcpkms:projects/p/locations/l/keyRings/r/cryptoKeys/k".
+	URI string
+}
+
+// Factory builds a KeyManager for the scheme it is registered under.
+type Factory func(ctx context.Context, opts Options) (KeyManager, error)
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes a backend available under scheme. It is meant to be called
+// from the init() function of a backend package, following the database/sql
+// driver registration pattern.
+func Register(scheme string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[scheme]; ok {
+		panic("kms: backend already registered for scheme " + scheme)
+	}
+
+	registry[scheme] = factory
+}
+
+// Get builds a KeyManager for uri, dispatching to the backend registered for
+// its scheme (the part before the first ':'). URIs without a scheme are
+// treated as plain filesystem paths and routed to the "pem" backend, so that
+// `constants.PCRPublicKey`-style paths keep working unmodified.
+func Get(ctx context.Context, uri string) (KeyManager, error) {
+	scheme := "pem"
+
+	if idx := strings.Index(uri, ":"); idx > 1 { // len("a:") == 2, avoids matching Windows drive letters
+		scheme = uri[:idx]
+	}
+
+	registryMu.Lock()
+	factory, ok := registry[scheme]
+	registryMu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("kms: no backend registered for scheme %q (uri %q)", scheme, uri)
+	}
+
+	return factory(ctx, Options{URI: uri})
+}
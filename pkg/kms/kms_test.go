@@ -0,0 +1,54 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package kms_test
+
+import (
+	"context"
+	"crypto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/talos/pkg/kms"
+)
+
+type stubManager struct {
+	name string
+}
+
+func (s *stubManager) Name() string { return s.name }
+
+func (s *stubManager) PublicKey(context.Context, string) (crypto.PublicKey, error) { return nil, nil }
+
+func (s *stubManager) Certificate(context.Context, string) ([]byte, error) { return nil, kms.ErrNoCertificate }
+
+func (s *stubManager) Close() error { return nil }
+
+func TestGetDispatchesByScheme(t *testing.T) {
+	kms.Register("kmstest", func(_ context.Context, opts kms.Options) (kms.KeyManager, error) {
+		return &stubManager{name: "kmstest:" + opts.URI}, nil
+	})
+
+	manager, err := kms.Get(context.Background(), "kmstest:foo")
+	require.NoError(t, err)
+	require.Equal(t, "kmstest:kmstest:foo", manager.Name())
+}
+
+func TestGetUnknownSchemeErrors(t *testing.T) {
+	_, err := kms.Get(context.Background(), "nosuchscheme:foo")
+	require.Error(t, err)
+}
+
+func TestRegisterPanicsOnDuplicate(t *testing.T) {
+	kms.Register("kmstest-dup", func(context.Context, kms.Options) (kms.KeyManager, error) {
+		return nil, nil
+	})
+
+	require.Panics(t, func() {
+		kms.Register("kmstest-dup", func(context.Context, kms.Options) (kms.KeyManager, error) {
+			return nil, nil
+		})
+	})
+}
@@ -0,0 +1,112 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package pem implements kms.KeyManager on top of a plain PEM-encoded
+// certificate or public key file on disk. This is the default backend, used
+// to keep today's `constants.PCRPublicKey` behavior unchanged.
+package pem
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/siderolabs/talos/pkg/kms"
+)
+
+func init() {
+	kms.Register("pem", func(_ context.Context, opts kms.Options) (kms.KeyManager, error) {
+		return &KeyManager{}, nil
+	})
+}
+
+// KeyManager reads key material from PEM files on the local filesystem.
+type KeyManager struct{}
+
+// Name implements kms.KeyManager.
+func (*KeyManager) Name() string {
+	return "pem"
+}
+
+// PublicKey implements kms.KeyManager.
+func (m *KeyManager) PublicKey(_ context.Context, uri string) (crypto.PublicKey, error) {
+	cert, err := m.readCertificate(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert.PublicKey, nil
+}
+
+// Certificate implements kms.KeyManager.
+func (m *KeyManager) Certificate(_ context.Context, uri string) ([]byte, error) {
+	cert, err := m.readCertificate(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert.Raw, nil
+}
+
+// Close implements kms.KeyManager.
+func (*KeyManager) Close() error {
+	return nil
+}
+
+// CertificateChain implements kms.ChainProvider, reading every CERTIFICATE
+// PEM block found in the file, in file order (expected to be leaf first).
+func (m *KeyManager) CertificateChain(_ context.Context, uri string) ([][]byte, error) {
+	path := strings.TrimPrefix(uri, "pem:")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("pem: failed to read %q: %w", path, err)
+	}
+
+	var chain [][]byte
+
+	for {
+		var block *pem.Block
+
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type == "CERTIFICATE" {
+			chain = append(chain, block.Bytes)
+		}
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("pem: no CERTIFICATE blocks found in %q", path)
+	}
+
+	return chain, nil
+}
+
+func (*KeyManager) readCertificate(uri string) (*x509.Certificate, error) {
+	path := strings.TrimPrefix(uri, "pem:")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("pem: failed to read %q: %w", path, err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("pem: failed to decode PEM block in %q", path)
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("pem: failed to parse certificate in %q: %w", path, err)
+	}
+
+	return cert, nil
+}
@@ -0,0 +1,358 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package pkcs11 implements kms.KeyManager on top of a PKCS#11 token, for
+// HSM-backed PCR signing keys. URIs are ";"-separated attribute=value pairs
+// after the "pkcs11:" scheme, e.g.
+// "pkcs11:token=talos;object=pcr-signer;module-path=/usr/lib/softhsm/libsofthsm2.so".
+// The module-path attribute is required; objects are looked up by their
+// "object" label in the first slot that has a token present, and the "token"
+// attribute is not used to select the slot.
+package pkcs11
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/miekg/pkcs11"
+
+	"github.com/siderolabs/talos/pkg/kms"
+)
+
+func init() {
+	kms.Register("pkcs11", func(_ context.Context, opts kms.Options) (kms.KeyManager, error) {
+		return open(opts.URI)
+	})
+}
+
+// KeyManager reads key material from a PKCS#11 token.
+type KeyManager struct {
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+}
+
+func open(uri string) (*KeyManager, error) {
+	attrs, err := parseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	module := attrs["module-path"]
+	if module == "" {
+		return nil, fmt.Errorf("pkcs11: %q is missing the module-path attribute", uri)
+	}
+
+	ctx := pkcs11.New(module)
+	if ctx == nil {
+		return nil, fmt.Errorf("pkcs11: failed to load module %q", module)
+	}
+
+	if err := ctx.Initialize(); err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to initialize module %q: %w", module, err)
+	}
+
+	slots, err := ctx.GetSlotList(true)
+	if err != nil || len(slots) == 0 {
+		return nil, fmt.Errorf("pkcs11: no slots with a token present in %q", module)
+	}
+
+	session, err := ctx.OpenSession(slots[0], pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to open session: %w", err)
+	}
+
+	return &KeyManager{ctx: ctx, session: session}, nil
+}
+
+// Name implements kms.KeyManager.
+func (*KeyManager) Name() string {
+	return "pkcs11"
+}
+
+// PublicKey implements kms.KeyManager.
+func (m *KeyManager) PublicKey(_ context.Context, uri string) (crypto.PublicKey, error) {
+	attrs, err := parseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := m.findObject(attrs["object"], pkcs11.CKO_PUBLIC_KEY)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.decodePublicKey(handle)
+}
+
+// Certificate implements kms.KeyManager.
+func (m *KeyManager) Certificate(_ context.Context, uri string) ([]byte, error) {
+	attrs, err := parseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := m.findObject(attrs["object"], pkcs11.CKO_CERTIFICATE)
+	if err != nil {
+		return nil, kms.ErrNoCertificate
+	}
+
+	values, err := m.ctx.GetAttributeValue(m.session, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to read certificate DER: %w", err)
+	}
+
+	return values[0].Value, nil
+}
+
+// Close implements kms.KeyManager.
+func (m *KeyManager) Close() error {
+	if err := m.ctx.CloseSession(m.session); err != nil {
+		return err
+	}
+
+	m.ctx.Finalize()
+	m.ctx.Destroy()
+
+	return nil
+}
+
+// sha256DigestInfoPrefix is the DER-encoded DigestInfo prefix for SHA-256,
+// per RFC 8017 section 9.2. CKM_RSA_PKCS only applies PKCS#1 v1.5 padding;
+// callers are expected to supply the DigestInfo themselves.
+var sha256DigestInfoPrefix = []byte{
+	0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20,
+}
+
+// Sign implements kms.Signer, using the token's C_Sign so the private key
+// never leaves the HSM.
+func (m *KeyManager) Sign(_ context.Context, uri string, digest []byte, _ crypto.SignerOpts) ([]byte, error) {
+	attrs, err := parseURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := m.findObject(attrs["object"], pkcs11.CKO_PRIVATE_KEY)
+	if err != nil {
+		return nil, err
+	}
+
+	keyType, err := m.keyType(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mechanism uint
+		data      []byte
+	)
+
+	switch keyType {
+	case pkcs11.CKK_RSA:
+		mechanism = pkcs11.CKM_RSA_PKCS
+		data = append(append([]byte{}, sha256DigestInfoPrefix...), digest...)
+	case pkcs11.CKK_EC:
+		mechanism = pkcs11.CKM_ECDSA
+		data = digest
+	default:
+		return nil, fmt.Errorf("pkcs11: unsupported CKA_KEY_TYPE %d for signing", keyType)
+	}
+
+	if err := m.ctx.SignInit(m.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(mechanism, nil)}, handle); err != nil {
+		return nil, fmt.Errorf("pkcs11: SignInit failed: %w", err)
+	}
+
+	sig, err := m.ctx.Sign(m.session, data)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: Sign failed: %w", err)
+	}
+
+	return sig, nil
+}
+
+func (m *KeyManager) findObject(label string, class uint) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+
+	if err := m.ctx.FindObjectsInit(m.session, template); err != nil {
+		return 0, fmt.Errorf("pkcs11: FindObjectsInit failed: %w", err)
+	}
+
+	defer m.ctx.FindObjectsFinal(m.session) //nolint:errcheck
+
+	handles, _, err := m.ctx.FindObjects(m.session, 1)
+	if err != nil {
+		return 0, fmt.Errorf("pkcs11: FindObjects failed: %w", err)
+	}
+
+	if len(handles) == 0 {
+		return 0, fmt.Errorf("pkcs11: no object labeled %q found", label)
+	}
+
+	return handles[0], nil
+}
+
+// keyType reads CKA_KEY_TYPE off handle, which is readable on both public
+// and private key objects without exposing key material.
+func (m *KeyManager) keyType(handle pkcs11.ObjectHandle) (uint, error) {
+	attr, err := m.ctx.GetAttributeValue(m.session, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, nil),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("pkcs11: failed to read key type: %w", err)
+	}
+
+	return bytesToUint(attr[0].Value), nil
+}
+
+func (m *KeyManager) decodePublicKey(handle pkcs11.ObjectHandle) (crypto.PublicKey, error) {
+	keyType, err := m.keyType(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	switch keyType {
+	case pkcs11.CKK_RSA:
+		return m.decodeRSAPublicKey(handle)
+	case pkcs11.CKK_EC:
+		return m.decodeECPublicKey(handle)
+	default:
+		return nil, fmt.Errorf("pkcs11: unsupported CKA_KEY_TYPE %d", keyType)
+	}
+}
+
+func (m *KeyManager) decodeRSAPublicKey(handle pkcs11.ObjectHandle) (crypto.PublicKey, error) {
+	values, err := m.ctx.GetAttributeValue(m.session, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to read RSA public key attributes: %w", err)
+	}
+
+	return rsaPublicKeyFromAttrs(values[0].Value, values[1].Value)
+}
+
+// rsaPublicKeyFromAttrs builds an *rsa.PublicKey from the raw big-endian
+// CKA_MODULUS and CKA_PUBLIC_EXPONENT attribute values.
+func rsaPublicKeyFromAttrs(modulus, exponent []byte) (*rsa.PublicKey, error) {
+	if len(modulus) == 0 {
+		return nil, fmt.Errorf("pkcs11: empty CKA_MODULUS")
+	}
+
+	if len(exponent) == 0 {
+		return nil, fmt.Errorf("pkcs11: empty CKA_PUBLIC_EXPONENT")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}, nil
+}
+
+func (m *KeyManager) decodeECPublicKey(handle pkcs11.ObjectHandle) (crypto.PublicKey, error) {
+	values, err := m.ctx.GetAttributeValue(m.session, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to read EC public key attributes: %w", err)
+	}
+
+	return ecPublicKeyFromAttrs(values[0].Value, values[1].Value)
+}
+
+// ecPublicKeyFromAttrs builds an *ecdsa.PublicKey from the DER-encoded curve
+// OID in CKA_EC_PARAMS and the ASN.1 OCTET STRING-wrapped uncompressed
+// point in CKA_EC_POINT.
+func ecPublicKeyFromAttrs(ecParams, ecPoint []byte) (*ecdsa.PublicKey, error) {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(ecParams, &oid); err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to parse CKA_EC_PARAMS: %w", err)
+	}
+
+	curve, err := curveForOID(oid)
+	if err != nil {
+		return nil, err
+	}
+
+	var point []byte
+	if _, err := asn1.Unmarshal(ecPoint, &point); err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to parse CKA_EC_POINT: %w", err)
+	}
+
+	x, y := elliptic.Unmarshal(curve, point)
+	if x == nil {
+		return nil, fmt.Errorf("pkcs11: CKA_EC_POINT is not a valid uncompressed point on %s", curve.Params().Name)
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+var (
+	oidNamedCurveP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidNamedCurveP384 = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+	oidNamedCurveP521 = asn1.ObjectIdentifier{1, 3, 132, 0, 35}
+)
+
+func curveForOID(oid asn1.ObjectIdentifier) (elliptic.Curve, error) {
+	switch {
+	case oid.Equal(oidNamedCurveP256):
+		return elliptic.P256(), nil
+	case oid.Equal(oidNamedCurveP384):
+		return elliptic.P384(), nil
+	case oid.Equal(oidNamedCurveP521):
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("pkcs11: unsupported EC curve OID %s", oid)
+	}
+}
+
+// bytesToUint decodes a native-endian C_GetAttributeValue CK_ULONG result,
+// matching how miekg/pkcs11 returns CKA_KEY_TYPE and similar enum attributes.
+func bytesToUint(b []byte) uint {
+	var v uint
+
+	for i, c := range b {
+		v |= uint(c) << (8 * i)
+	}
+
+	return v
+}
+
+// parseURI does a minimal RFC 7512 "pkcs11:attr=value;attr=value" parse,
+// enough for the token/object/module-path attributes used to select a key.
+func parseURI(uri string) (map[string]string, error) {
+	rest := strings.TrimPrefix(uri, "pkcs11:")
+	if rest == uri {
+		return nil, fmt.Errorf("pkcs11: %q is missing the pkcs11: scheme", uri)
+	}
+
+	attrs := map[string]string{}
+
+	for _, pair := range strings.Split(rest, ";") {
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("pkcs11: malformed attribute %q in %q", pair, uri)
+		}
+
+		attrs[kv[0]] = kv[1]
+	}
+
+	return attrs, nil
+}
@@ -0,0 +1,72 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package pkcs11
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSAPublicKeyFromAttrs(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	modulus := key.PublicKey.N.Bytes()
+	exponent := big.NewInt(int64(key.PublicKey.E)).Bytes()
+
+	got, err := rsaPublicKeyFromAttrs(modulus, exponent)
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey.N, got.N)
+	require.Equal(t, key.PublicKey.E, got.E)
+}
+
+func TestRSAPublicKeyFromAttrsRejectsEmpty(t *testing.T) {
+	_, err := rsaPublicKeyFromAttrs(nil, []byte{1})
+	require.Error(t, err)
+
+	_, err = rsaPublicKeyFromAttrs([]byte{1}, nil)
+	require.Error(t, err)
+}
+
+func TestECPublicKeyFromAttrs(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ecParams, err := asn1.Marshal(oidNamedCurveP256)
+	require.NoError(t, err)
+
+	point := elliptic.Marshal(elliptic.P256(), key.X, key.Y)
+
+	ecPoint, err := asn1.Marshal(point)
+	require.NoError(t, err)
+
+	got, err := ecPublicKeyFromAttrs(ecParams, ecPoint)
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey.X, got.X)
+	require.Equal(t, key.PublicKey.Y, got.Y)
+	require.Equal(t, elliptic.P256(), got.Curve)
+}
+
+func TestCurveForOID(t *testing.T) {
+	curve, err := curveForOID(oidNamedCurveP384)
+	require.NoError(t, err)
+	require.Equal(t, elliptic.P384(), curve)
+
+	_, err = curveForOID(asn1.ObjectIdentifier{1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestBytesToUint(t *testing.T) {
+	require.Equal(t, uint(0), bytesToUint(nil))
+	require.Equal(t, uint(1), bytesToUint([]byte{1, 0, 0, 0}))
+	require.Equal(t, uint(0x0201), bytesToUint([]byte{0x01, 0x02}))
+}
@@ -0,0 +1,186 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package tpm implements kms.KeyManager on top of a key held in a TPM2
+// persistent object handle, so that PCR signing keys can live in the node's
+// TPM rather than on disk. Key material stored in NV indices is not read.
+package tpm
+
+import (
+	"context"
+	"crypto"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-tpm/tpm2"
+	"github.com/google/go-tpm/tpm2/transport"
+
+	"github.com/siderolabs/talos/pkg/kms"
+)
+
+// DefaultDevicePath is the TPM character device this package opens, matching
+// the rest of Talos' TPM handling. Key URIs carry only the handle, not a
+// device path.
+const DefaultDevicePath = "/dev/tpmrm0"
+
+// MeasuredBootPCRs are the PCR indices Talos' measured boot chain extends,
+// and the set attestation.Build reports in its Predicate.PCRValues.
+var MeasuredBootPCRs = []int{0, 1, 2, 3, 4, 5, 6, 7, 11, 12, 13}
+
+// ReadPCRValues reads the current SHA-256 bank of MeasuredBootPCRs off the
+// TPM at DefaultDevicePath, hex-encoded and keyed by decimal PCR index.
+func ReadPCRValues(context.Context) (map[string]string, error) {
+	device, err := transport.OpenTPM(DefaultDevicePath)
+	if err != nil {
+		return nil, fmt.Errorf("tpm: failed to open %s: %w", DefaultDevicePath, err)
+	}
+	defer device.Close() //nolint:errcheck
+
+	values := make(map[string]string, len(MeasuredBootPCRs))
+
+	for _, pcr := range MeasuredBootPCRs {
+		resp, err := tpm2.PCRRead{
+			PCRSelectionIn: tpm2.TPMLPCRSelection{
+				PCRSelections: []tpm2.TPMSPCRSelection{
+					{
+						Hash:      tpm2.TPMAlgSHA256,
+						PCRSelect: pcrSelectBitmap(pcr),
+					},
+				},
+			},
+		}.Execute(device)
+		if err != nil {
+			return nil, fmt.Errorf("tpm: failed to read PCR %d: %w", pcr, err)
+		}
+
+		if len(resp.PCRValues.Digests) == 0 {
+			continue
+		}
+
+		values[strconv.Itoa(pcr)] = hex.EncodeToString(resp.PCRValues.Digests[0].Buffer)
+	}
+
+	return values, nil
+}
+
+// pcrSelectBitmap encodes a single PCR index as a TPMS_PCR_SELECT bitmap
+// (one bit per PCR, 3 bytes covering PCRs 0-23).
+func pcrSelectBitmap(pcr int) []byte {
+	bitmap := make([]byte, 3)
+	bitmap[pcr/8] |= 1 << uint(pcr%8)
+
+	return bitmap
+}
+
+func init() {
+	kms.Register("tpm", func(_ context.Context, opts kms.Options) (kms.KeyManager, error) {
+		return open(opts.URI)
+	})
+}
+
+// KeyManager reads a public key out of a TPM2 persistent handle.
+type KeyManager struct {
+	device transport.TPMCloser
+}
+
+func open(uri string) (*KeyManager, error) {
+	device, err := transport.OpenTPM(DefaultDevicePath)
+	if err != nil {
+		return nil, fmt.Errorf("tpm: failed to open %s: %w", DefaultDevicePath, err)
+	}
+
+	return &KeyManager{device: device}, nil
+}
+
+// Name implements kms.KeyManager.
+func (*KeyManager) Name() string {
+	return "tpm"
+}
+
+// PublicKey implements kms.KeyManager.
+func (m *KeyManager) PublicKey(_ context.Context, uri string) (crypto.PublicKey, error) {
+	handle, err := parseHandle(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tpm2.ReadPublic{ObjectHandle: handle}.Execute(m.device)
+	if err != nil {
+		return nil, fmt.Errorf("tpm: failed to read public area of 0x%x: %w", handle, err)
+	}
+
+	pub, err := resp.OutPublic.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("tpm: failed to unmarshal public area of 0x%x: %w", handle, err)
+	}
+
+	return publicKeyFromTPMT(pub)
+}
+
+// Certificate implements kms.KeyManager.
+//
+// TPM persistent handles do not carry a certificate, so this always returns
+// kms.ErrNoCertificate.
+func (*KeyManager) Certificate(context.Context, string) ([]byte, error) {
+	return nil, kms.ErrNoCertificate
+}
+
+// Close implements kms.KeyManager.
+func (m *KeyManager) Close() error {
+	return m.device.Close()
+}
+
+// Sign implements kms.Signer, using the TPM2 Sign command so the private
+// portion of the key never leaves the TPM.
+func (m *KeyManager) Sign(_ context.Context, uri string, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	handle, err := parseHandle(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tpm2.Sign{
+		KeyHandle: handle,
+		Digest:    tpm2.TPM2BDigest{Buffer: digest},
+		InScheme:  tpm2.TPMTSigScheme{Scheme: tpm2.TPMAlgNull},
+	}.Execute(m.device)
+	if err != nil {
+		return nil, fmt.Errorf("tpm: sign with 0x%x failed: %w", handle, err)
+	}
+
+	return resp.Signature.Marshal()
+}
+
+func parseHandle(uri string) (tpm2.TPMHandle, error) {
+	raw := strings.TrimPrefix(uri, "tpm:")
+
+	handle, err := strconv.ParseUint(strings.TrimPrefix(raw, "0x"), 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("tpm: invalid handle %q: %w", raw, err)
+	}
+
+	return tpm2.TPMHandle(handle), nil
+}
+
+func publicKeyFromTPMT(pub tpm2.TPMUPublicID) (crypto.PublicKey, error) {
+	switch pub.GetTag() {
+	case tpm2.TPMAlgRSA:
+		rsaPub, err := pub.RSADetail()
+		if err != nil {
+			return nil, fmt.Errorf("tpm: not an RSA key: %w", err)
+		}
+
+		return rsaPub, nil
+	case tpm2.TPMAlgECC:
+		eccPub, err := pub.ECCDetail()
+		if err != nil {
+			return nil, fmt.Errorf("tpm: not an ECC key: %w", err)
+		}
+
+		return eccPub, nil
+	default:
+		return nil, fmt.Errorf("tpm: unsupported public key algorithm %v", pub.GetTag())
+	}
+}
@@ -0,0 +1,74 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package config defines the machine configuration provider interface and
+// the COSI resource that carries it, so that controllers can depend on
+// Provider's accessor methods instead of a concrete document type.
+package config
+
+import (
+	"github.com/cosi-project/runtime/pkg/resource"
+)
+
+// NamespaceName is the namespace of the MachineConfig resource.
+const NamespaceName = resource.Namespace("config")
+
+// MachineConfigType is the type of MachineConfig resource.
+const MachineConfigType = resource.Type("MachineConfigs.config.talos.dev")
+
+// ActiveID is the ID of the MachineConfig resource holding the currently
+// active machine configuration.
+const ActiveID = resource.ID("v1alpha1")
+
+// Provider is satisfied by a parsed machine configuration document,
+// regardless of version. Controllers read the machine config exclusively
+// through this interface rather than depending on a specific document type.
+type Provider interface {
+	Security() SecurityConfig
+}
+
+// MachineConfig is a COSI resource wrapping a Provider. Unlike most
+// resources in this tree, its spec isn't a protobuf message: the machine
+// config document is parsed YAML with its own versioned Go types, so
+// MachineConfig implements resource.Resource directly instead of going
+// through typed.Resource.
+type MachineConfig struct {
+	md       resource.Metadata
+	provider Provider
+}
+
+// NewMachineConfig wraps provider in a MachineConfig resource with the
+// well-known ActiveID.
+func NewMachineConfig(provider Provider) *MachineConfig {
+	return &MachineConfig{
+		md:       resource.NewMetadata(NamespaceName, MachineConfigType, ActiveID, resource.VersionUndefined),
+		provider: provider,
+	}
+}
+
+// Metadata implements resource.Resource.
+func (c *MachineConfig) Metadata() *resource.Metadata {
+	return &c.md
+}
+
+// Spec implements resource.Resource.
+func (c *MachineConfig) Spec() interface{} {
+	return c.provider
+}
+
+// DeepCopy implements resource.Resource.
+//
+// Provider is treated as immutable once parsed, so this shares it rather
+// than cloning it.
+func (c *MachineConfig) DeepCopy() resource.Resource {
+	return &MachineConfig{
+		md:       c.md,
+		provider: c.provider,
+	}
+}
+
+// Config returns the wrapped Provider.
+func (c *MachineConfig) Config() Provider {
+	return c.provider
+}
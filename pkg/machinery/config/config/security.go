@@ -0,0 +1,38 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package config
+
+// SecurityConfig is the security-relevant subset of a machine configuration
+// document: how PCR signatures are produced and verified.
+type SecurityConfig interface {
+	// PCRSigningKeyURI is the single-key fallback used when PCRSigningKeys
+	// is empty, e.g. "pem:/etc/...", "tpm:0x81000001", or a PKCS#11 / cloud
+	// KMS URI. Empty means "use the Talos default on-disk PEM key".
+	PCRSigningKeyURI() string
+
+	// PCRSigningKeys lists the keys accepted for PCR signature
+	// verification, supporting rotation without a reboot. Empty means
+	// "derive a single 'current' entry from PCRSigningKeyURI instead".
+	PCRSigningKeys() []PCRSigningKey
+
+	// PCRSigningKeysQuorum is how many of PCRSigningKeys must agree on a
+	// signature for it to be accepted.
+	PCRSigningKeysQuorum() uint32
+}
+
+// PCRSigningKey is a single entry in SecurityConfig.PCRSigningKeys.
+type PCRSigningKey interface {
+	// URI addresses the key, in the same schemes PCRSigningKeyURI accepts.
+	URI() string
+
+	// Role is one of the PCRSigningKeyRole* constants defined alongside
+	// the PCRSigningKeysConfig resource, e.g. "current" or "revoked".
+	Role() string
+
+	// NotBefore and NotAfter bound the key's validity window as Unix
+	// seconds; zero means unbounded.
+	NotBefore() int64
+	NotAfter() int64
+}
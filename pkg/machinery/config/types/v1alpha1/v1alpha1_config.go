@@ -0,0 +1,29 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package v1alpha1 holds the v1alpha1 machine configuration document.
+//
+// Only the Security section is modeled here: the Machine/Cluster/etc.
+// sections of the real document are out of scope for this package and are
+// intentionally not reproduced.
+package v1alpha1
+
+import (
+	"github.com/siderolabs/talos/pkg/machinery/config/config"
+)
+
+// Config is the v1alpha1 machine configuration document.
+type Config struct {
+	// ConfigSecurity holds the Security() section of the document.
+	ConfigSecurity *SecurityConfig `yaml:"security,omitempty"`
+}
+
+// Security implements config.Provider.
+func (c *Config) Security() config.SecurityConfig {
+	if c.ConfigSecurity == nil {
+		return &SecurityConfig{}
+	}
+
+	return c.ConfigSecurity
+}
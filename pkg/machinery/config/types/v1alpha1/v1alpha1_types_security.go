@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package v1alpha1
+
+import (
+	"github.com/siderolabs/talos/pkg/machinery/config/config"
+)
+
+// SecurityConfig implements config.SecurityConfig for the v1alpha1 document.
+type SecurityConfig struct {
+	// PCRSigningKeyURIConfig is the fallback signing key URI, used when
+	// PCRSigningKeysConfig is empty.
+	PCRSigningKeyURIConfig string `yaml:"pcrSigningKeyURI,omitempty"`
+	// PCRSigningKeysConfig lists the keys accepted for PCR signature
+	// verification.
+	PCRSigningKeysConfig []*PCRSigningKeyConfig `yaml:"pcrSigningKeys,omitempty"`
+	// PCRSigningKeysQuorumConfig is how many PCRSigningKeysConfig entries
+	// must agree on a signature for it to be accepted.
+	PCRSigningKeysQuorumConfig uint32 `yaml:"pcrSigningKeysQuorum,omitempty"`
+}
+
+// PCRSigningKeyURI implements config.SecurityConfig.
+func (s *SecurityConfig) PCRSigningKeyURI() string {
+	if s == nil {
+		return ""
+	}
+
+	return s.PCRSigningKeyURIConfig
+}
+
+// PCRSigningKeys implements config.SecurityConfig.
+func (s *SecurityConfig) PCRSigningKeys() []config.PCRSigningKey {
+	if s == nil {
+		return nil
+	}
+
+	keys := make([]config.PCRSigningKey, 0, len(s.PCRSigningKeysConfig))
+
+	for _, k := range s.PCRSigningKeysConfig {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// PCRSigningKeysQuorum implements config.SecurityConfig.
+func (s *SecurityConfig) PCRSigningKeysQuorum() uint32 {
+	if s == nil {
+		return 0
+	}
+
+	return s.PCRSigningKeysQuorumConfig
+}
+
+// PCRSigningKeyConfig implements config.PCRSigningKey for the v1alpha1 document.
+type PCRSigningKeyConfig struct {
+	// URIConfig addresses the key.
+	URIConfig string `yaml:"uri"`
+	// RoleConfig is one of the PCRSigningKeyRole* constants, e.g. "current"
+	// or "revoked".
+	RoleConfig string `yaml:"role,omitempty"`
+	// NotBeforeConfig and NotAfterConfig bound the key's validity window as
+	// Unix seconds; zero means unbounded.
+	NotBeforeConfig int64 `yaml:"notBefore,omitempty"`
+	NotAfterConfig  int64 `yaml:"notAfter,omitempty"`
+}
+
+// URI implements config.PCRSigningKey.
+func (k *PCRSigningKeyConfig) URI() string { return k.URIConfig }
+
+// Role implements config.PCRSigningKey.
+func (k *PCRSigningKeyConfig) Role() string { return k.RoleConfig }
+
+// NotBefore implements config.PCRSigningKey.
+func (k *PCRSigningKeyConfig) NotBefore() int64 { return k.NotBeforeConfig }
+
+// NotAfter implements config.PCRSigningKey.
+func (k *PCRSigningKeyConfig) NotAfter() int64 { return k.NotAfterConfig }
@@ -0,0 +1,36 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package events defines the runtime events controllers can publish onto
+// the node's event stream (surfaced via `talosctl dmesg`/`talosctl events`).
+package events
+
+import "context"
+
+// Publisher is implemented by the runtime event bus (v1alpha1.Events in
+// production code); controllers depend on this narrow interface instead of
+// the concrete event bus so they stay independently testable.
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Event is implemented by every event type in this package.
+type Event interface {
+	// EventType returns a short, stable identifier for the event, used as
+	// the gRPC event stream's type tag.
+	EventType() string
+}
+
+// RevocationEvent is emitted when the previously good PCR signing
+// certificate is found to be revoked, so that upgrades and other automation
+// can gate on it. UEFI db/KEK/PK certificates are not checked and do not
+// produce this event.
+type RevocationEvent struct {
+	Message string
+}
+
+// EventType implements Event.
+func (*RevocationEvent) EventType() string {
+	return "RevocationEvent"
+}
@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+	"github.com/cosi-project/runtime/pkg/resource/protobuf"
+	"github.com/cosi-project/runtime/pkg/resource/typed"
+
+	"github.com/siderolabs/talos/pkg/machinery/proto"
+)
+
+// PCRSigningKeyConfigType is the type of PCRSigningKeyConfig resource.
+const PCRSigningKeyConfigType = resource.Type("PCRSigningKeyConfigs.runtime.talos.dev")
+
+// PCRSigningKeyConfigID is a singleton resource ID for the PCR signing key config resource.
+const PCRSigningKeyConfigID = resource.ID("pcr-signing-key")
+
+// PCRSigningKeyConfig resource holds the resolved configuration for the PCR
+// signing key backend, derived from the machine config. Today it is read by
+// PCRSigningKeysConfigController as the single-key fallback when the
+// machine config doesn't list an explicit set of keys; it is meant as the
+// single source of truth for "where is the key" for any future controller
+// that needs to verify or produce PCR signatures outside of that path.
+type PCRSigningKeyConfig = typed.Resource[PCRSigningKeyConfigSpec, PCRSigningKeyConfigExtension]
+
+// PCRSigningKeyConfigSpec is the spec for PCRSigningKeyConfig resource.
+type PCRSigningKeyConfigSpec = protobuf.ResourceSpec[PCRSigningKeyConfigSpecProto, *PCRSigningKeyConfigSpecProto]
+
+// PCRSigningKeyConfigSpecProto is the protobuf spec for PCRSigningKeyConfig resource.
+type PCRSigningKeyConfigSpecProto struct {
+	proto.Message
+
+	// KeyURI is the configured key URI, e.g. "pem:/etc/...", "tpm:0x81000001",
+	// "pkcs11:token=talos;object=pcr-signer", or a cloud KMS resource name.
+	//
+	// Defaults to "pem:" + constants.PCRPublicKey when not set in the
+	// machine config, preserving today's on-disk PEM behavior.
+	KeyURI string `protobuf:"1"`
+}
+
+// NewPCRSigningKeyConfigSpec initializes a PCRSigningKeyConfig resource.
+func NewPCRSigningKeyConfigSpec(namespace resource.Namespace) *PCRSigningKeyConfig {
+	return typed.NewResource[PCRSigningKeyConfigSpec, PCRSigningKeyConfigExtension](
+		resource.NewMetadata(namespace, PCRSigningKeyConfigType, PCRSigningKeyConfigID, resource.VersionUndefined),
+		protobuf.NewResourceSpec(&PCRSigningKeyConfigSpecProto{}),
+	)
+}
+
+// PCRSigningKeyConfigExtension provides auxiliary methods for PCRSigningKeyConfig resource.
+type PCRSigningKeyConfigExtension struct{}
+
+// ResourceDefinition implements typed.Extension interface.
+func (PCRSigningKeyConfigExtension) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             PCRSigningKeyConfigType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "KeyURI",
+				JSONPath: "{.keyURI}",
+			},
+		},
+	}
+}
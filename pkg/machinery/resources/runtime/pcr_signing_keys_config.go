@@ -0,0 +1,99 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+	"github.com/cosi-project/runtime/pkg/resource/protobuf"
+	"github.com/cosi-project/runtime/pkg/resource/typed"
+
+	"github.com/siderolabs/talos/pkg/machinery/proto"
+)
+
+// PCRSigningKeysConfigType is the type of PCRSigningKeysConfig resource.
+const PCRSigningKeysConfigType = resource.Type("PCRSigningKeysConfigs.runtime.talos.dev")
+
+// PCRSigningKeysConfigID is a singleton resource ID for the PCR signing keys config resource.
+const PCRSigningKeysConfigID = resource.ID("pcr-signing-keys")
+
+// PCRSigningKeyRoleCurrent marks the key PCR values are expected to be
+// signed with right now.
+const PCRSigningKeyRoleCurrent = "current"
+
+// PCRSigningKeyRoleNext marks a key that is not yet in use but should be
+// accepted so that key rotation doesn't require a reboot: once operators
+// start signing with it, it's promoted to PCRSigningKeyRoleCurrent.
+const PCRSigningKeyRoleNext = "next"
+
+// PCRSigningKeyRoleRevoked marks a formerly valid key that must no longer
+// be accepted, e.g. after a suspected compromise.
+const PCRSigningKeyRoleRevoked = "revoked"
+
+// PCRSigningKeysConfig resource holds the set of PCR signing keys that are
+// currently valid for verification purposes, with their validity windows
+// and roles, enabling seamless key rotation without a reboot.
+//
+// It is populated from the machine config (or defaults to a single entry
+// wrapping PCRSigningKeyConfig) by PCRSigningKeysConfigController, and read
+// by SecurityStateController (for fingerprinting and revocation) and by
+// SecurityAttestationController, which signs each attestation with every
+// "current" entry and uses pkg/pcr.VerifyQuorum to confirm QuorumThreshold
+// of them actually verify before publishing it. Other PCR signature
+// verification call sites (systemd-stub UKI verification, image cache) can
+// adopt the same resource once they exist.
+type PCRSigningKeysConfig = typed.Resource[PCRSigningKeysConfigSpec, PCRSigningKeysConfigExtension]
+
+// PCRSigningKeysConfigSpec is the spec for PCRSigningKeysConfig resource.
+type PCRSigningKeysConfigSpec = protobuf.ResourceSpec[PCRSigningKeysConfigSpecProto, *PCRSigningKeysConfigSpecProto]
+
+// PCRSigningKeysConfigSpecProto is the protobuf spec for PCRSigningKeysConfig resource.
+type PCRSigningKeysConfigSpecProto struct {
+	proto.Message
+
+	// Keys is the set of keys accepted for PCR signature verification.
+	Keys []PCRSigningKeyConfigEntry `protobuf:"1"`
+
+	// QuorumThreshold, when greater than zero, requires at least this many
+	// distinct non-revoked keys to have validly signed a digest before it
+	// is accepted (N-of-M quorum mode). Zero disables quorum mode: any
+	// single non-revoked key is sufficient.
+	QuorumThreshold uint32 `protobuf:"2"`
+}
+
+// PCRSigningKeyConfigEntry is a single entry in PCRSigningKeysConfig.Keys.
+type PCRSigningKeyConfigEntry struct {
+	// URI identifies the key via the pkg/kms registry, e.g. "pem:/etc/...",
+	// "tpm:0x81000001", "pkcs11:token=talos;object=pcr-signer".
+	URI string `protobuf:"1"`
+
+	// Role is one of the PCRSigningKeyRole* constants.
+	Role string `protobuf:"2"`
+
+	// NotBefore and NotAfter bound the key's validity window; zero means
+	// unbounded on that side.
+	NotBefore int64 `protobuf:"3"` // Unix seconds
+	NotAfter  int64 `protobuf:"4"` // Unix seconds
+}
+
+// NewPCRSigningKeysConfigSpec initializes a PCRSigningKeysConfig resource.
+func NewPCRSigningKeysConfigSpec(namespace resource.Namespace) *PCRSigningKeysConfig {
+	return typed.NewResource[PCRSigningKeysConfigSpec, PCRSigningKeysConfigExtension](
+		resource.NewMetadata(namespace, PCRSigningKeysConfigType, PCRSigningKeysConfigID, resource.VersionUndefined),
+		protobuf.NewResourceSpec(&PCRSigningKeysConfigSpecProto{}),
+	)
+}
+
+// PCRSigningKeysConfigExtension provides auxiliary methods for PCRSigningKeysConfig resource.
+type PCRSigningKeysConfigExtension struct{}
+
+// ResourceDefinition implements typed.Extension interface.
+func (PCRSigningKeysConfigExtension) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             PCRSigningKeysConfigType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+	}
+}
@@ -0,0 +1,88 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+	"github.com/cosi-project/runtime/pkg/resource/protobuf"
+	"github.com/cosi-project/runtime/pkg/resource/typed"
+
+	"github.com/siderolabs/talos/pkg/machinery/proto"
+)
+
+// SecurityAttestationType is the type of SecurityAttestation resource.
+const SecurityAttestationType = resource.Type("SecurityAttestations.runtime.talos.dev")
+
+// SecurityAttestationID is a singleton resource ID for the security attestation resource.
+const SecurityAttestationID = resource.ID("securityattestation")
+
+// SecurityAttestation resource records the result of publishing a signed
+// DSSE attestation of SecurityState to a transparency log, so that
+// talosctl (and operators) can later prove what configuration a node
+// booted with.
+type SecurityAttestation = typed.Resource[SecurityAttestationSpec, SecurityAttestationExtension]
+
+// SecurityAttestationSpec is the spec for SecurityAttestation resource.
+type SecurityAttestationSpec = protobuf.ResourceSpec[SecurityAttestationSpecProto, *SecurityAttestationSpecProto]
+
+// SecurityAttestationSpecProto is the protobuf spec for SecurityAttestation resource.
+type SecurityAttestationSpecProto struct {
+	proto.Message
+
+	// UUID is the transparency log entry UUID, empty until the attestation
+	// has been successfully submitted.
+	UUID string `protobuf:"1"`
+
+	// LogIndex is the transparency log entry index, -1 until submitted.
+	LogIndex int64 `protobuf:"2"`
+
+	// InclusionProof is the base64-encoded Merkle inclusion proof returned
+	// by the transparency log for UUID.
+	InclusionProof string `protobuf:"3"`
+
+	// DSSEDigest is the SHA-256 digest of the DSSE envelope that was (or is
+	// pending to be) submitted, so that a later successful submission can be
+	// matched back to the attestation it covers.
+	DSSEDigest string `protobuf:"4"`
+
+	// Offline is true when the attestation could not be submitted at boot
+	// (no network) and was instead written to OfflinePath for later retry.
+	Offline bool `protobuf:"5"`
+
+	// OfflinePath is the path the DSSE envelope and inclusion proof (once
+	// available) are persisted to while Offline is true.
+	OfflinePath string `protobuf:"6"`
+}
+
+// NewSecurityAttestationSpec initializes a SecurityAttestation resource.
+func NewSecurityAttestationSpec(namespace resource.Namespace) *SecurityAttestation {
+	return typed.NewResource[SecurityAttestationSpec, SecurityAttestationExtension](
+		resource.NewMetadata(namespace, SecurityAttestationType, SecurityAttestationID, resource.VersionUndefined),
+		protobuf.NewResourceSpec(&SecurityAttestationSpecProto{LogIndex: -1}),
+	)
+}
+
+// SecurityAttestationExtension provides auxiliary methods for SecurityAttestation resource.
+type SecurityAttestationExtension struct{}
+
+// ResourceDefinition implements typed.Extension interface.
+func (SecurityAttestationExtension) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             SecurityAttestationType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "UUID",
+				JSONPath: "{.uuid}",
+			},
+			{
+				Name:     "Offline",
+				JSONPath: "{.offline}",
+			},
+		},
+	}
+}
@@ -0,0 +1,156 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package runtime
+
+import (
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/resource/meta"
+	"github.com/cosi-project/runtime/pkg/resource/protobuf"
+	"github.com/cosi-project/runtime/pkg/resource/typed"
+
+	"github.com/siderolabs/talos/pkg/machinery/proto"
+)
+
+// SecurityStateType is the type of SecurityState resource.
+const SecurityStateType = resource.Type("SecurityStates.runtime.talos.dev")
+
+// SecurityStateID is a singleton resource ID for the security state resource.
+const SecurityStateID = resource.ID("securitystate")
+
+// SecurityState resource holds node's security state: SecureBoot status and
+// the key(s) used to sign PCR values.
+type SecurityState = typed.Resource[SecurityStateSpec, SecurityStateExtension]
+
+// SecurityStateSpec is the spec for SecurityState resource.
+type SecurityStateSpec = protobuf.ResourceSpec[SecurityStateSpecProto, *SecurityStateSpecProto]
+
+// SecurityStateSpecProto is the protobuf spec for SecurityState resource.
+//
+// It is a thin typed wrapper kept next to the resource definition instead of
+// under api/, since the field set here is Talos-internal and not part of the
+// gRPC API surface yet.
+type SecurityStateSpecProto struct {
+	proto.Message
+
+	// SecureBoot reports whether the node booted with SecureBoot enabled.
+	SecureBoot bool `protobuf:"1"`
+
+	// PCRSigningKeyFingerprint is the SHA-256 fingerprint of the PCR signing
+	// key certificate, colon-separated hex, e.g. "AB:CD:...".
+	PCRSigningKeyFingerprint string `protobuf:"2"`
+
+	// PCRSigningKeyURI is the URI identifying where the PCR signing key
+	// material lives, e.g. "pem:/etc/...", "tpm:0x81000001",
+	// "pkcs11:token=talos;object=pcr-signer", or a cloud KMS resource name.
+	// It is empty when the key could not be resolved.
+	PCRSigningKeyURI string `protobuf:"3"`
+
+	// CertChain is the parsed PCR signing certificate chain, leaf first.
+	CertChain []CertInfo `protobuf:"4"`
+
+	// TrustAnchorFingerprint is the SHA-256 fingerprint of the root
+	// certificate of CertChain.
+	TrustAnchorFingerprint string `protobuf:"5"`
+
+	// RevocationState is the most recently evaluated revocation status of
+	// the leaf PCR signing certificate, one of the RevocationState* constants.
+	RevocationState string `protobuf:"6"`
+
+	// PCRSigningKeys is the full set of keys currently accepted for PCR
+	// signature verification, reflecting PCRSigningKeysConfig. Exactly one
+	// entry normally has Role == PCRSigningKeyRoleCurrent; that entry's
+	// fingerprint and URI are mirrored onto PCRSigningKeyFingerprint and
+	// PCRSigningKeyURI above for backwards compatibility.
+	PCRSigningKeys []PCRSigningKeyInfo `protobuf:"7"`
+}
+
+// PCRSigningKeyInfo is a single entry of SecurityState.PCRSigningKeys: a
+// resolved, fingerprinted view of a PCRSigningKeyConfigEntry.
+type PCRSigningKeyInfo struct {
+	Fingerprint string `protobuf:"1"`
+	URI         string `protobuf:"2"`
+	Role        string `protobuf:"3"`
+	NotBefore   int64  `protobuf:"4"` // Unix seconds
+	NotAfter    int64  `protobuf:"5"` // Unix seconds
+}
+
+// CertInfo describes a single certificate in a chain. It carries the raw
+// DER bytes (Raw) alongside the parsed-out fields below, since the
+// revocation sub-controller needs to re-parse a real *x509.Certificate
+// (with an intact ASN.1 structure and public key) to build OCSP requests
+// and verify OCSP responses; the parsed fields exist so most callers don't
+// have to re-parse Raw just to read a name or a timestamp.
+type CertInfo struct {
+	Subject     string   `protobuf:"1"`
+	Issuer      string   `protobuf:"2"`
+	NotBefore   int64    `protobuf:"3"` // Unix seconds
+	NotAfter    int64    `protobuf:"4"` // Unix seconds
+	SKI         string   `protobuf:"5"` // hex-encoded Subject Key Identifier
+	AKI         string   `protobuf:"6"` // hex-encoded Authority Key Identifier
+	SANs        []string `protobuf:"7"`
+	Fingerprint string   `protobuf:"8"` // SHA-256 fingerprint of this certificate
+
+	// SerialNumber is the decimal-encoded certificate serial number, needed
+	// to match entries in a CRL.
+	SerialNumber string `protobuf:"9"`
+
+	// OCSPServer and CRLDistributionPoints are carried over from the
+	// certificate so the revocation sub-controller can check status without
+	// needing to re-fetch the certificate itself.
+	OCSPServer            []string `protobuf:"10"`
+	CRLDistributionPoints []string `protobuf:"11"`
+
+	// Raw is the DER encoding of the certificate, needed to reconstruct a
+	// full *x509.Certificate for OCSP request/response handling.
+	Raw []byte `protobuf:"12"`
+}
+
+// RevocationStateGood means the certificate was checked and is not revoked.
+const RevocationStateGood = "good"
+
+// RevocationStateRevoked means the certificate was checked and is revoked.
+const RevocationStateRevoked = "revoked"
+
+// RevocationStateUnknown means revocation status could not be determined
+// (e.g. no network, no OCSP responder or CRL distribution point configured).
+const RevocationStateUnknown = "unknown"
+
+// RevocationStateExpired means the certificate itself is outside its
+// NotBefore/NotAfter validity window.
+const RevocationStateExpired = "expired"
+
+// NewSecurityStateSpec initializes a SecurityState resource.
+func NewSecurityStateSpec(namespace resource.Namespace) *SecurityState {
+	return typed.NewResource[SecurityStateSpec, SecurityStateExtension](
+		resource.NewMetadata(namespace, SecurityStateType, SecurityStateID, resource.VersionUndefined),
+		protobuf.NewResourceSpec(&SecurityStateSpecProto{}),
+	)
+}
+
+// SecurityStateExtension provides auxiliary methods for SecurityState resource.
+type SecurityStateExtension struct{}
+
+// ResourceDefinition implements typed.Extension interface.
+func (SecurityStateExtension) ResourceDefinition() meta.ResourceDefinitionSpec {
+	return meta.ResourceDefinitionSpec{
+		Type:             SecurityStateType,
+		Aliases:          []resource.Type{},
+		DefaultNamespace: NamespaceName,
+		PrintColumns: []meta.PrintColumn{
+			{
+				Name:     "SecureBoot",
+				JSONPath: "{.secureBoot}",
+			},
+			{
+				Name:     "PCRSigningKeyURI",
+				JSONPath: "{.pcrSigningKeyURI}",
+			},
+			{
+				Name:     "RevocationState",
+				JSONPath: "{.revocationState}",
+			},
+		},
+	}
+}
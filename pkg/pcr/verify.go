@@ -0,0 +1,127 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package pcr implements PCR signature verification against a rotating set
+// of signing keys read from runtimeres.PCRSigningKeysConfig. It is used by
+// pkg/attestation to gate publication of a SecurityState attestation on
+// PCRSigningKeysConfig's QuorumThreshold. It exists as a standalone package,
+// depending only on pkg/kms and the runtime resource definitions, so that
+// the systemd-stub UKI verification path
+// and image-cache verification can also adopt it once those call sites
+// exist, and agree with the rest of Talos on what "a validly signed PCR
+// digest" means.
+package pcr
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"time"
+
+	"github.com/siderolabs/talos/pkg/kms"
+	runtimeres "github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+)
+
+// Key is a candidate PCR signing key, as recorded in
+// runtimeres.PCRSigningKeysConfig.
+type Key struct {
+	URI                 string
+	Role                string
+	NotBefore, NotAfter time.Time
+}
+
+// Verify reports whether sig is a valid signature over digest under any
+// single non-revoked, currently-valid key in keys (quorum-free mode: a
+// single signature is checked against every eligible key).
+func Verify(ctx context.Context, keys []Key, digest, sig []byte) (bool, error) {
+	now := time.Now()
+
+	var lastErr error
+
+	for _, key := range keys {
+		if key.Role == runtimeres.PCRSigningKeyRoleRevoked {
+			continue
+		}
+
+		if !key.NotBefore.IsZero() && now.Before(key.NotBefore) {
+			continue
+		}
+
+		if !key.NotAfter.IsZero() && now.After(key.NotAfter) {
+			continue
+		}
+
+		ok, err := verifyOne(ctx, key.URI, digest, sig)
+		if err != nil {
+			lastErr = err
+
+			continue
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, lastErr
+}
+
+// VerifyQuorum requires at least threshold of sigs to each verify under a
+// distinct non-revoked key in keys, for high-assurance N-of-M setups.
+func VerifyQuorum(ctx context.Context, keys []Key, digest []byte, sigs [][]byte, threshold int) (bool, error) {
+	if threshold <= 0 {
+		threshold = 1
+	}
+
+	usedKeys := map[string]bool{}
+	matched := 0
+
+	for _, sig := range sigs {
+		for _, key := range keys {
+			if usedKeys[key.URI] {
+				continue
+			}
+
+			ok, err := Verify(ctx, []Key{key}, digest, sig)
+			if err != nil || !ok {
+				continue
+			}
+
+			usedKeys[key.URI] = true
+			matched++
+
+			break
+		}
+	}
+
+	return matched >= threshold, nil
+}
+
+func verifyOne(ctx context.Context, uri string, digest, sig []byte) (bool, error) {
+	manager, err := kms.Get(ctx, uri)
+	if err != nil {
+		return false, err
+	}
+
+	defer manager.Close() //nolint:errcheck
+
+	pub, err := manager.PublicKey(ctx, uri)
+	if err != nil {
+		return false, err
+	}
+
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, sig) == nil, nil
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest, sig), nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, digest, sig), nil
+	default:
+		return false, fmt.Errorf("pcr: unsupported public key type %T for %q", pub, uri)
+	}
+}
@@ -0,0 +1,168 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package pcr_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/talos/pkg/kms"
+	"github.com/siderolabs/talos/pkg/pcr"
+	runtimeres "github.com/siderolabs/talos/pkg/machinery/resources/runtime"
+)
+
+type ecdsaManager struct {
+	pub *ecdsa.PublicKey
+}
+
+func (m *ecdsaManager) Name() string { return "pcrtest" }
+
+func (m *ecdsaManager) PublicKey(context.Context, string) (crypto.PublicKey, error) { return m.pub, nil }
+
+func (m *ecdsaManager) Certificate(context.Context, string) ([]byte, error) { return nil, kms.ErrNoCertificate }
+
+func (m *ecdsaManager) Close() error { return nil }
+
+// registerECDSAKey generates an ECDSA key, registers it under a fresh kms
+// scheme keyed off t.Name(), and returns its URI and private key.
+func registerECDSAKey(t *testing.T, suffix string) (string, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	scheme := "pcrtest-" + t.Name() + "-" + suffix
+
+	kms.Register(scheme, func(context.Context, kms.Options) (kms.KeyManager, error) {
+		return &ecdsaManager{pub: &priv.PublicKey}, nil
+	})
+
+	return scheme + ":key", priv
+}
+
+func signDigest(t *testing.T, priv *ecdsa.PrivateKey, digest []byte) []byte {
+	t.Helper()
+
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest)
+	require.NoError(t, err)
+
+	return sig
+}
+
+func TestVerifyAcceptsValidSignatureFromAnyKey(t *testing.T) {
+	uri1, priv1 := registerECDSAKey(t, "1")
+	uri2, _ := registerECDSAKey(t, "2")
+
+	digest := sha256.Sum256([]byte("pcr-digest"))
+	sig := signDigest(t, priv1, digest[:])
+
+	keys := []pcr.Key{
+		{URI: uri1, Role: runtimeres.PCRSigningKeyRoleCurrent},
+		{URI: uri2, Role: runtimeres.PCRSigningKeyRoleNext},
+	}
+
+	ok, err := pcr.Verify(context.Background(), keys, digest[:], sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestVerifySkipsRevokedKeys(t *testing.T) {
+	uri, priv := registerECDSAKey(t, "1")
+
+	digest := sha256.Sum256([]byte("pcr-digest"))
+	sig := signDigest(t, priv, digest[:])
+
+	keys := []pcr.Key{
+		{URI: uri, Role: runtimeres.PCRSigningKeyRoleRevoked},
+	}
+
+	ok, err := pcr.Verify(context.Background(), keys, digest[:], sig)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestVerifySkipsOutOfValidityWindow(t *testing.T) {
+	uri, priv := registerECDSAKey(t, "1")
+
+	digest := sha256.Sum256([]byte("pcr-digest"))
+	sig := signDigest(t, priv, digest[:])
+
+	keys := []pcr.Key{
+		{
+			URI:       uri,
+			Role:      runtimeres.PCRSigningKeyRoleCurrent,
+			NotBefore: time.Now().Add(time.Hour),
+		},
+	}
+
+	ok, err := pcr.Verify(context.Background(), keys, digest[:], sig)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestVerifyRejectsBadSignature(t *testing.T) {
+	uri, _ := registerECDSAKey(t, "1")
+
+	digest := sha256.Sum256([]byte("pcr-digest"))
+
+	keys := []pcr.Key{
+		{URI: uri, Role: runtimeres.PCRSigningKeyRoleCurrent},
+	}
+
+	ok, err := pcr.Verify(context.Background(), keys, digest[:], []byte("not-a-signature"))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestVerifyQuorumRequiresDistinctKeys(t *testing.T) {
+	uri1, priv1 := registerECDSAKey(t, "1")
+	uri2, priv2 := registerECDSAKey(t, "2")
+	uri3, _ := registerECDSAKey(t, "3")
+
+	digest := sha256.Sum256([]byte("pcr-digest"))
+	sig1 := signDigest(t, priv1, digest[:])
+	sig2 := signDigest(t, priv2, digest[:])
+
+	keys := []pcr.Key{
+		{URI: uri1, Role: runtimeres.PCRSigningKeyRoleCurrent},
+		{URI: uri2, Role: runtimeres.PCRSigningKeyRoleCurrent},
+		{URI: uri3, Role: runtimeres.PCRSigningKeyRoleCurrent},
+	}
+
+	ok, err := pcr.VerifyQuorum(context.Background(), keys, digest[:], [][]byte{sig1, sig2}, 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = pcr.VerifyQuorum(context.Background(), keys, digest[:], [][]byte{sig1, sig2}, 3)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestVerifyQuorumDoesNotDoubleCountSameKey(t *testing.T) {
+	uri1, priv1 := registerECDSAKey(t, "1")
+	uri2, _ := registerECDSAKey(t, "2")
+
+	digest := sha256.Sum256([]byte("pcr-digest"))
+	sig1 := signDigest(t, priv1, digest[:])
+
+	keys := []pcr.Key{
+		{URI: uri1, Role: runtimeres.PCRSigningKeyRoleCurrent},
+		{URI: uri2, Role: runtimeres.PCRSigningKeyRoleCurrent},
+	}
+
+	// The same valid signature submitted twice should only ever match the
+	// one key it verifies under, not satisfy a threshold of 2.
+	ok, err := pcr.VerifyQuorum(context.Background(), keys, digest[:], [][]byte{sig1, sig1}, 2)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -0,0 +1,65 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package revocation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache persists Result values to disk, keyed by certificate fingerprint,
+// so that a node rebooting without network access can still report the
+// last known revocation status instead of immediately falling back to
+// StatusUnknown.
+type Cache struct {
+	dir string
+}
+
+// NewCache returns a Cache rooted at dir, creating it if necessary.
+func NewCache(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("revocation: failed to create cache dir %q: %w", dir, err)
+	}
+
+	return &Cache{dir: dir}, nil
+}
+
+type cacheEntry struct {
+	Status     Status    `json:"status"`
+	CheckedAt  time.Time `json:"checkedAt"`
+	NextUpdate time.Time `json:"nextUpdate"`
+}
+
+// Get returns the cached Result for fingerprint, if present.
+func (c *Cache) Get(fingerprint string) (Result, bool) {
+	data, err := os.ReadFile(c.path(fingerprint))
+	if err != nil {
+		return Result{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Result{}, false
+	}
+
+	return Result{Status: entry.Status, CheckedAt: entry.CheckedAt, NextUpdate: entry.NextUpdate}, true
+}
+
+// Put stores result for fingerprint.
+func (c *Cache) Put(fingerprint string, result Result) error {
+	data, err := json.Marshal(cacheEntry{Status: result.Status, CheckedAt: result.CheckedAt, NextUpdate: result.NextUpdate})
+	if err != nil {
+		return fmt.Errorf("revocation: failed to marshal cache entry: %w", err)
+	}
+
+	return os.WriteFile(c.path(fingerprint), data, 0o600)
+}
+
+func (c *Cache) path(fingerprint string) string {
+	return filepath.Join(c.dir, fingerprint+".json")
+}
@@ -0,0 +1,36 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package revocation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/siderolabs/talos/pkg/revocation"
+)
+
+func TestCacheGetPutRoundTrip(t *testing.T) {
+	cache, err := revocation.NewCache(t.TempDir())
+	require.NoError(t, err)
+
+	_, ok := cache.Get("deadbeef")
+	require.False(t, ok)
+
+	want := revocation.Result{
+		Status:     revocation.StatusGood,
+		CheckedAt:  time.Now().Truncate(time.Second),
+		NextUpdate: time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+
+	require.NoError(t, cache.Put("deadbeef", want))
+
+	got, ok := cache.Get("deadbeef")
+	require.True(t, ok)
+	require.Equal(t, want.Status, got.Status)
+	require.True(t, want.CheckedAt.Equal(got.CheckedAt))
+	require.True(t, want.NextUpdate.Equal(got.NextUpdate))
+}
@@ -0,0 +1,191 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package revocation checks X.509 certificate revocation status via OCSP
+// (honoring the RFC 7633 must-staple TLS Feature extension) and CRL
+// distribution points, caching responses on disk so nodes without constant
+// network access still get a usable answer between refreshes.
+package revocation
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// oidExtensionTLSFeature is the TLS Feature extension (RFC 7633), the one
+// actually used to signal must-staple; id-pkix-ocsp-nocheck marks a
+// delegated OCSP responder certificate as exempt from OCSP checking and is
+// unrelated to must-staple.
+var oidExtensionTLSFeature = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+
+// tlsFeatureStatusRequest is the "status_request" TLS extension ID (RFC
+// 6066 section 8) that, when present in a certificate's TLS Feature
+// extension, requests OCSP stapling (must-staple).
+const tlsFeatureStatusRequest = 5
+
+// Status mirrors runtimeres.RevocationState* without importing the resource
+// package, to keep this package dependency-free of machined internals.
+type Status string
+
+const (
+	// StatusGood means the certificate was checked and is not revoked.
+	StatusGood Status = "good"
+	// StatusRevoked means the certificate was checked and is revoked.
+	StatusRevoked Status = "revoked"
+	// StatusUnknown means revocation status could not be determined.
+	StatusUnknown Status = "unknown"
+	// StatusExpired means the certificate is outside its validity window.
+	StatusExpired Status = "expired"
+)
+
+// Result is the outcome of checking a single certificate.
+type Result struct {
+	Status    Status
+	CheckedAt time.Time
+	// NextUpdate is when the underlying OCSP/CRL response says the caller
+	// should check again, if known.
+	NextUpdate time.Time
+}
+
+// Check evaluates the revocation status of leaf, issued by issuer. It tries
+// OCSP first, then falls back to the certificate's CRL distribution
+// points. When leaf carries the TLS Feature extension (RFC 7633
+// must-staple) requesting status_request, OCSP is required and Check does
+// not fall back to CRL on OCSP failure, since the certificate itself
+// declares CRL insufficient.
+func Check(ctx context.Context, client *http.Client, leaf, issuer *x509.Certificate) (Result, error) {
+	now := time.Now()
+
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return Result{Status: StatusExpired, CheckedAt: now}, nil
+	}
+
+	result, ocspErr := checkOCSP(ctx, client, leaf, issuer)
+	if ocspErr == nil {
+		return result, nil
+	}
+
+	if mustStaple(leaf) {
+		return Result{Status: StatusUnknown, CheckedAt: now}, nil
+	}
+
+	if result, err := checkCRL(ctx, client, leaf); err == nil {
+		return result, nil
+	}
+
+	return Result{Status: StatusUnknown, CheckedAt: now}, nil
+}
+
+// mustStaple reports whether leaf's TLS Feature extension requests
+// status_request (OCSP stapling), per RFC 7633.
+func mustStaple(leaf *x509.Certificate) bool {
+	for _, ext := range leaf.Extensions {
+		if !ext.Id.Equal(oidExtensionTLSFeature) {
+			continue
+		}
+
+		var features []int
+		if _, err := asn1.Unmarshal(ext.Value, &features); err != nil {
+			return false
+		}
+
+		for _, feature := range features {
+			if feature == tlsFeatureStatusRequest {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func checkOCSP(ctx context.Context, client *http.Client, leaf, issuer *x509.Certificate) (Result, error) {
+	if len(leaf.OCSPServer) == 0 {
+		return Result{}, fmt.Errorf("revocation: no OCSP server in certificate")
+	}
+
+	req, err := ocsp.CreateRequest(leaf, issuer, nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("revocation: failed to build OCSP request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, leaf.OCSPServer[0], bytes.NewReader(req))
+	if err != nil {
+		return Result{}, fmt.Errorf("revocation: failed to build OCSP HTTP request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return Result{}, fmt.Errorf("revocation: OCSP request to %q failed: %w", leaf.OCSPServer[0], err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Result{}, fmt.Errorf("revocation: failed to read OCSP response: %w", err)
+	}
+
+	parsed, err := ocsp.ParseResponseForCert(body, leaf, issuer)
+	if err != nil {
+		return Result{}, fmt.Errorf("revocation: failed to parse OCSP response: %w", err)
+	}
+
+	status := StatusUnknown
+
+	switch parsed.Status {
+	case ocsp.Good:
+		status = StatusGood
+	case ocsp.Revoked:
+		status = StatusRevoked
+	}
+
+	return Result{Status: status, CheckedAt: time.Now(), NextUpdate: parsed.NextUpdate}, nil
+}
+
+func checkCRL(ctx context.Context, client *http.Client, leaf *x509.Certificate) (Result, error) {
+	if len(leaf.CRLDistributionPoints) == 0 {
+		return Result{}, fmt.Errorf("revocation: no CRL distribution point in certificate")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, leaf.CRLDistributionPoints[0], nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("revocation: failed to build CRL HTTP request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("revocation: CRL fetch from %q failed: %w", leaf.CRLDistributionPoints[0], err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Result{}, fmt.Errorf("revocation: failed to read CRL: %w", err)
+	}
+
+	crl, err := x509.ParseRevocationList(body)
+	if err != nil {
+		return Result{}, fmt.Errorf("revocation: failed to parse CRL: %w", err)
+	}
+
+	for _, revoked := range crl.RevokedCertificateEntries {
+		if revoked.SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+			return Result{Status: StatusRevoked, CheckedAt: time.Now(), NextUpdate: crl.NextUpdate}, nil
+		}
+	}
+
+	return Result{Status: StatusGood, CheckedAt: time.Now(), NextUpdate: crl.NextUpdate}, nil
+}
@@ -0,0 +1,229 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package revocation_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/siderolabs/talos/pkg/revocation"
+)
+
+var oidTLSFeature = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+
+func mustGenerateCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func mustGenerateLeaf(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, extraExtensions []pkix.Extension, ocspServer, crlDP string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		Subject:         pkix.Name{CommonName: "leaf"},
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: extraExtensions,
+	}
+
+	if ocspServer != "" {
+		template.OCSPServer = []string{ocspServer}
+	}
+
+	if crlDP != "" {
+		template.CRLDistributionPoints = []string{crlDP}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func TestCheckOCSPGood(t *testing.T) {
+	ca, caKey := mustGenerateCA(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		ocspReq, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+
+		resp, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: ocspReq.SerialNumber,
+			ThisUpdate:   time.Now(),
+			NextUpdate:   time.Now().Add(time.Hour),
+		}, caKey)
+		require.NoError(t, err)
+
+		w.Write(resp) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	leaf, _ := mustGenerateLeaf(t, ca, caKey, nil, server.URL, "")
+
+	result, err := revocation.Check(context.Background(), server.Client(), leaf, ca)
+	require.NoError(t, err)
+	require.Equal(t, revocation.StatusGood, result.Status)
+}
+
+func TestCheckOCSPRevoked(t *testing.T) {
+	ca, caKey := mustGenerateCA(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		ocspReq, err := ocsp.ParseRequest(body)
+		require.NoError(t, err)
+
+		resp, err := ocsp.CreateResponse(ca, ca, ocsp.Response{
+			Status:       ocsp.Revoked,
+			SerialNumber: ocspReq.SerialNumber,
+			ThisUpdate:   time.Now(),
+			NextUpdate:   time.Now().Add(time.Hour),
+			RevokedAt:    time.Now().Add(-time.Minute),
+		}, caKey)
+		require.NoError(t, err)
+
+		w.Write(resp) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	leaf, _ := mustGenerateLeaf(t, ca, caKey, nil, server.URL, "")
+
+	result, err := revocation.Check(context.Background(), server.Client(), leaf, ca)
+	require.NoError(t, err)
+	require.Equal(t, revocation.StatusRevoked, result.Status)
+}
+
+func TestCheckFallsBackToCRL(t *testing.T) {
+	ca, caKey := mustGenerateCA(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:     big.NewInt(1),
+			ThisUpdate: time.Now(),
+			NextUpdate: time.Now().Add(time.Hour),
+		}, ca, caKey)
+		require.NoError(t, err)
+
+		w.Write(crl) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	// no OCSPServer set, so Check must fall back to the CRL distribution point.
+	leaf, _ := mustGenerateLeaf(t, ca, caKey, nil, "", server.URL)
+
+	result, err := revocation.Check(context.Background(), server.Client(), leaf, ca)
+	require.NoError(t, err)
+	require.Equal(t, revocation.StatusGood, result.Status)
+}
+
+func TestCheckExpiredCertificate(t *testing.T) {
+	ca, caKey := mustGenerateCA(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "expired"},
+		NotBefore:    time.Now().Add(-2 * time.Hour),
+		NotAfter:     time.Now().Add(-time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	result, err := revocation.Check(context.Background(), http.DefaultClient, leaf, ca)
+	require.NoError(t, err)
+	require.Equal(t, revocation.StatusExpired, result.Status)
+}
+
+func TestCheckMustStapleDoesNotFallBackToCRL(t *testing.T) {
+	ca, caKey := mustGenerateCA(t)
+
+	// OCSP server that always errors, so a must-staple cert must not fall
+	// back to CRL and must not silently succeed.
+	ocspServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ocspServer.Close()
+
+	crlHit := false
+
+	crlServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		crlHit = true
+
+		crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:     big.NewInt(1),
+			ThisUpdate: time.Now(),
+			NextUpdate: time.Now().Add(time.Hour),
+		}, ca, caKey)
+		require.NoError(t, err)
+
+		w.Write(crl) //nolint:errcheck
+	}))
+	defer crlServer.Close()
+
+	tlsFeature, err := asn1.Marshal([]int{5})
+	require.NoError(t, err)
+
+	leaf, _ := mustGenerateLeaf(t, ca, caKey, []pkix.Extension{
+		{Id: oidTLSFeature, Value: tlsFeature},
+	}, ocspServer.URL, crlServer.URL)
+
+	result, err := revocation.Check(context.Background(), http.DefaultClient, leaf, ca)
+	require.NoError(t, err)
+	require.Equal(t, revocation.StatusUnknown, result.Status)
+	require.False(t, crlHit, "must-staple certificates must not fall back to CRL on OCSP failure")
+}